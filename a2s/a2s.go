@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+// Package a2s implements the Steam server query protocol (A2S_INFO,
+// A2S_PLAYER and A2S_RULES) in pure Go, for tools that query game servers
+// without loading the Steamworks library. Its Responder answers the same
+// queries for game servers that are not logged on to Steam.
+//
+// Results use the GameServerItem and ServerPlayer types of the steamworks
+// package, as returned by its ISteamMatchmakingServers wrappers.
+package a2s
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/badhex/go-steamworks"
+)
+
+var (
+	ErrMalformed   = errors.New("a2s: malformed packet")
+	ErrUnexpected  = errors.New("a2s: unexpected response type")
+	ErrTooManyHops = errors.New("a2s: server kept sending challenges")
+)
+
+// Packet headers and message types of the protocol.
+const (
+	headerSimple int32 = -1
+	headerSplit  int32 = -2
+
+	typeInfoRequest    = 'T'
+	typePlayerRequest  = 'U'
+	typeRulesRequest   = 'V'
+	typeInfoResponse   = 'I'
+	typePlayerResponse = 'D'
+	typeRulesResponse  = 'E'
+	typeChallenge      = 'A'
+
+	infoPayload = "Source Engine Query\x00"
+
+	// maxPacketSize is the largest datagram servers send; split packets
+	// carry at most this much.
+	maxPacketSize = 1400
+	// noChallenge requests a challenge number from the server.
+	noChallenge int32 = -1
+)
+
+// Extra data flags of A2S_INFO responses.
+const (
+	edfGameID   = 0x01
+	edfSteamID  = 0x10
+	edfKeywords = 0x20
+	edfSourceTV = 0x40
+	edfPort     = 0x80
+)
+
+// Server types and environments reported by A2S_INFO.
+const (
+	ServerTypeDedicated    byte = 'd'
+	ServerTypeNonDedicated byte = 'l'
+	ServerTypeSourceTV     byte = 'p'
+
+	EnvironmentLinux   byte = 'l'
+	EnvironmentWindows byte = 'w'
+	EnvironmentMac     byte = 'm'
+)
+
+// Info is an A2S_INFO response. The embedded GameServerItem holds the fields
+// Steam's server browser reports; Addr is the address that was queried, with
+// the connection port taken from the response when the server sends one.
+type Info struct {
+	steamworks.GameServerItem
+	Protocol     byte
+	ServerType   byte
+	Environment  byte
+	Version      string
+	GameID       uint64
+	SourceTVPort uint16
+	SourceTVName string
+}
+
+// serverVersion turns a dotted version string such as "1.0.2.3" into the
+// integer form of gameserveritem_t's server version, 1023.
+func serverVersion(version string) int {
+	n, err := strconv.Atoi(strings.ReplaceAll(version, ".", ""))
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,277 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package a2s
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/badhex/go-steamworks"
+)
+
+// DefaultTimeout bounds a query, including its challenge round trips, when
+// Client.Timeout is zero.
+const DefaultTimeout = 3 * time.Second
+
+// maxChallenges is how many challenges a query answers before giving up.
+const maxChallenges = 3
+
+// Client queries game servers over UDP. The zero value is ready to use and
+// safe for concurrent use.
+type Client struct {
+	// Timeout bounds each query. Zero uses DefaultTimeout; the context's
+	// deadline applies when it is earlier.
+	Timeout time.Duration
+}
+
+// Info sends A2S_INFO to the query address addr ("host:port").
+func (c *Client) Info(ctx context.Context, addr string) (*Info, error) {
+	request := func(challenge int32) []byte {
+		b := appendHeader(nil, typeInfoRequest)
+		b = append(b, infoPayload...)
+		if challenge != noChallenge {
+			b = binary.LittleEndian.AppendUint32(b, uint32(challenge))
+		}
+		return b
+	}
+	resp, err := c.query(ctx, addr, request, typeInfoResponse)
+	if err != nil {
+		return nil, err
+	}
+	return parseInfo(resp.body, resp.remote, resp.rtt)
+}
+
+// Players sends A2S_PLAYER to the query address addr ("host:port").
+func (c *Client) Players(ctx context.Context, addr string) ([]steamworks.ServerPlayer, error) {
+	resp, err := c.query(ctx, addr, challengeRequest(typePlayerRequest), typePlayerResponse)
+	if err != nil {
+		return nil, err
+	}
+	return parsePlayers(resp.body)
+}
+
+// Rules sends A2S_RULES to the query address addr ("host:port").
+func (c *Client) Rules(ctx context.Context, addr string) (map[string]string, error) {
+	resp, err := c.query(ctx, addr, challengeRequest(typeRulesRequest), typeRulesResponse)
+	if err != nil {
+		return nil, err
+	}
+	return parseRules(resp.body)
+}
+
+// appendHeader appends the simple header, -1 as a little-endian int32, and
+// the message type.
+func appendHeader(b []byte, typ byte) []byte {
+	return append(b, 0xFF, 0xFF, 0xFF, 0xFF, typ)
+}
+
+// challengeRequest builds requests that always carry a challenge number,
+// starting with noChallenge.
+func challengeRequest(typ byte) func(int32) []byte {
+	return func(challenge int32) []byte {
+		return binary.LittleEndian.AppendUint32(appendHeader(nil, typ), uint32(challenge))
+	}
+}
+
+type response struct {
+	body   []byte
+	remote netip.AddrPort
+	rtt    time.Duration
+}
+
+// query sends request until the server answers with a packet of type want,
+// answering challenges on the way.
+func (c *Client) query(ctx context.Context, addr string, request func(challenge int32) []byte, want byte) (response, error) {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return response{}, err
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return response{}, err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	remote := conn.RemoteAddr().(*net.UDPAddr).AddrPort()
+	challenge := noChallenge
+	for range maxChallenges + 1 {
+		start := time.Now()
+		if _, err := conn.Write(request(challenge)); err != nil {
+			return response{}, contextError(ctx, err)
+		}
+		packet, err := readResponse(conn)
+		if err != nil {
+			return response{}, contextError(ctx, err)
+		}
+		r := &reader{b: packet}
+		switch typ := r.uint8(); {
+		case r.err != nil:
+			return response{}, r.err
+		case typ == typeChallenge:
+			challenge = r.int32()
+			if r.err != nil {
+				return response{}, r.err
+			}
+		case typ == want:
+			return response{body: r.b, remote: remote, rtt: time.Since(start)}, nil
+		default:
+			return response{}, ErrUnexpected
+		}
+	}
+	return response{}, ErrTooManyHops
+}
+
+// contextError reports the context's error for I/O cut short by it.
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+// readResponse reads datagrams until a complete packet has arrived and returns
+// it without its simple header.
+func readResponse(conn net.Conn) ([]byte, error) {
+	buf := make([]byte, 1<<16)
+	var split splitAssembler
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		packet := buf[:n]
+		if n < 4 {
+			return nil, ErrMalformed
+		}
+		switch int32(binary.LittleEndian.Uint32(packet)) {
+		case headerSimple:
+			return packet[4:], nil
+		case headerSplit:
+			p, err := parseSplitPacket(packet[4:])
+			if err != nil {
+				return nil, err
+			}
+			p.payload = append([]byte(nil), p.payload...)
+			data, done, err := split.add(p)
+			if err != nil {
+				return nil, err
+			}
+			if !done {
+				continue
+			}
+			if len(data) < 4 || int32(binary.LittleEndian.Uint32(data)) != headerSimple {
+				return nil, ErrMalformed
+			}
+			return data[4:], nil
+		default:
+			return nil, ErrMalformed
+		}
+	}
+}
+
+func parseInfo(b []byte, remote netip.AddrPort, rtt time.Duration) (*Info, error) {
+	r := &reader{b: b}
+	info := &Info{Protocol: r.uint8()}
+	item := &info.GameServerItem
+	item.Name = r.string()
+	item.Map = r.string()
+	item.GameDir = r.string()
+	item.GameDescription = r.string()
+	item.AppID = steamworks.AppId_t(r.uint16())
+	item.Players = int(r.uint8())
+	item.MaxPlayers = int(r.uint8())
+	item.BotPlayers = int(r.uint8())
+	info.ServerType = r.uint8()
+	info.Environment = r.uint8()
+	item.Password = r.uint8() != 0
+	item.Secure = r.uint8() != 0
+	info.Version = r.string()
+	item.ServerVersion = serverVersion(info.Version)
+
+	item.Addr.QueryPort = remote.Port()
+	item.Addr.ConnectionPort = remote.Port()
+	if ip := remote.Addr().Unmap(); ip.Is4() {
+		item.Addr.IP = binary.BigEndian.Uint32(ip.AsSlice())
+	}
+	item.Ping = rtt
+	item.HadSuccessfulResponse = true
+
+	if len(r.b) > 0 {
+		edf := r.uint8()
+		if edf&edfPort != 0 {
+			item.Addr.ConnectionPort = r.uint16()
+		}
+		if edf&edfSteamID != 0 {
+			item.SteamID = steamworks.CSteamID(r.uint64())
+		}
+		if edf&edfSourceTV != 0 {
+			info.SourceTVPort = r.uint16()
+			info.SourceTVName = r.string()
+		}
+		if edf&edfKeywords != 0 {
+			if keywords := r.string(); keywords != "" {
+				item.Tags = strings.Split(keywords, ",")
+			}
+		}
+		if edf&edfGameID != 0 {
+			info.GameID = r.uint64()
+			// The low 24 bits hold the full app ID, which the 16-bit field
+			// above truncates.
+			item.AppID = steamworks.AppId_t(info.GameID & 0xFFFFFF)
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return info, nil
+}
+
+func parsePlayers(b []byte) ([]steamworks.ServerPlayer, error) {
+	r := &reader{b: b}
+	count := int(r.uint8())
+	players := make([]steamworks.ServerPlayer, 0, count)
+	for i := 0; i < count && r.err == nil; i++ {
+		r.uint8() // index, always 0 on current servers
+		p := steamworks.ServerPlayer{Name: r.string(), Score: int(r.int32())}
+		p.TimePlayed = time.Duration(float64(r.float32()) * float64(time.Second))
+		players = append(players, p)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return players, nil
+}
+
+func parseRules(b []byte) (map[string]string, error) {
+	r := &reader{b: b}
+	count := int(r.uint16())
+	rules := make(map[string]string, count)
+	for i := 0; i < count && r.err == nil; i++ {
+		name := r.string()
+		rules[name] = r.string()
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return rules, nil
+}
@@ -0,0 +1,323 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package a2s
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/badhex/go-steamworks"
+)
+
+const testChallenge int32 = 0x12345678
+
+// responder is a local UDP server that answers each request with the
+// datagrams returned by handle.
+type responder struct {
+	conn   net.PacketConn
+	handle func(req []byte) [][]byte
+}
+
+func newResponder(t *testing.T, handle func(req []byte) [][]byte) *responder {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &responder{conn: conn, handle: handle}
+	t.Cleanup(func() { conn.Close() })
+	go r.serve()
+	return r
+}
+
+func (r *responder) addr() string {
+	return r.conn.LocalAddr().String()
+}
+
+func (r *responder) serve() {
+	buf := make([]byte, 1<<16)
+	for {
+		n, from, err := r.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		for _, packet := range r.handle(slices.Clone(buf[:n])) {
+			r.conn.WriteTo(packet, from)
+		}
+	}
+}
+
+// challenged answers requests of type typ that lack testChallenge with a
+// challenge, and the rest with the datagrams returned by answer.
+func challenged(typ byte, answer func() [][]byte) func([]byte) [][]byte {
+	return func(req []byte) [][]byte {
+		if len(req) < 5 || req[4] != typ {
+			return nil
+		}
+		if len(req) < 9 || int32(binary.LittleEndian.Uint32(req[len(req)-4:])) != testChallenge {
+			return [][]byte{challengePacket()}
+		}
+		return answer()
+	}
+}
+
+func challengePacket() []byte {
+	return binary.LittleEndian.AppendUint32(appendHeader(nil, typeChallenge), uint32(testChallenge))
+}
+
+// splitPackets splits a packet into n Source split fragments.
+func splitPackets(id int32, packet []byte, n int, compressedSize int, crc uint32) [][]byte {
+	var out [][]byte
+	size := (len(packet) + n - 1) / n
+	for i := 0; i < n; i++ {
+		part := packet[min(i*size, len(packet)):min((i+1)*size, len(packet))]
+		b := []byte{0xFE, 0xFF, 0xFF, 0xFF}
+		b = binary.LittleEndian.AppendUint32(b, uint32(id))
+		b = append(b, byte(n), byte(i))
+		b = binary.LittleEndian.AppendUint16(b, maxPacketSize)
+		if id < 0 && i == 0 {
+			b = binary.LittleEndian.AppendUint32(b, uint32(compressedSize))
+			b = binary.LittleEndian.AppendUint32(b, crc)
+		}
+		out = append(out, append(b, part...))
+	}
+	// Deliver out of order to exercise reassembly.
+	slices.Reverse(out)
+	return out
+}
+
+func testInfoResponse() []byte {
+	b := appendHeader(nil, typeInfoResponse)
+	b = append(b, 17)
+	b = append(b, "Test Server\x00de_dust2\x00csgo\x00Counter-Strike\x00"...)
+	b = binary.LittleEndian.AppendUint16(b, 730)
+	b = append(b, 5, 16, 2, ServerTypeDedicated, EnvironmentLinux, 1, 1)
+	b = append(b, "1.38.7.9\x00"...)
+	b = append(b, edfPort|edfSteamID|edfSourceTV|edfKeywords|edfGameID)
+	b = binary.LittleEndian.AppendUint16(b, 27015)
+	b = binary.LittleEndian.AppendUint64(b, 90071992547409920)
+	b = binary.LittleEndian.AppendUint16(b, 27020)
+	b = append(b, "tv\x00"...)
+	b = append(b, "secure,casual\x00"...)
+	return binary.LittleEndian.AppendUint64(b, 730)
+}
+
+func TestClientInfo(t *testing.T) {
+	r := newResponder(t, func(req []byte) [][]byte {
+		if !bytes.HasPrefix(req, appendHeader(nil, typeInfoRequest)) || !bytes.Contains(req, []byte(infoPayload)) {
+			return nil
+		}
+		if len(req) == 5+len(infoPayload) {
+			return [][]byte{challengePacket()}
+		}
+		return [][]byte{testInfoResponse()}
+	})
+
+	var c Client
+	info, err := c.Info(context.Background(), r.addr())
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	item := info.GameServerItem
+	if item.Name != "Test Server" || item.Map != "de_dust2" || item.GameDir != "csgo" || item.GameDescription != "Counter-Strike" {
+		t.Errorf("strings = %q %q %q %q", item.Name, item.Map, item.GameDir, item.GameDescription)
+	}
+	if item.AppID != 730 || item.Players != 5 || item.MaxPlayers != 16 || item.BotPlayers != 2 {
+		t.Errorf("counts = %d %d/%d %d", item.AppID, item.Players, item.MaxPlayers, item.BotPlayers)
+	}
+	if !item.Password || !item.Secure || !item.HadSuccessfulResponse {
+		t.Errorf("flags = %+v", item)
+	}
+	if item.ServerVersion != 13879 || info.Version != "1.38.7.9" {
+		t.Errorf("version = %q %d", info.Version, item.ServerVersion)
+	}
+	if item.SteamID != 90071992547409920 || info.GameID != 730 {
+		t.Errorf("ids = %d %d", item.SteamID, info.GameID)
+	}
+	if info.SourceTVPort != 27020 || info.SourceTVName != "tv" {
+		t.Errorf("SourceTV = %d %q", info.SourceTVPort, info.SourceTVName)
+	}
+	if !slices.Equal(item.Tags, []string{"secure", "casual"}) {
+		t.Errorf("Tags = %q", item.Tags)
+	}
+	if info.ServerType != ServerTypeDedicated || info.Environment != EnvironmentLinux || info.Protocol != 17 {
+		t.Errorf("type = %c %c %d", info.ServerType, info.Environment, info.Protocol)
+	}
+	queryPort := r.conn.LocalAddr().(*net.UDPAddr).AddrPort().Port()
+	if got := item.Addr.QueryAddr().String(); got != fmt.Sprintf("127.0.0.1:%d", queryPort) {
+		t.Errorf("QueryAddr = %s", got)
+	}
+	if item.Addr.ConnectionPort != 27015 {
+		t.Errorf("ConnectionPort = %d", item.Addr.ConnectionPort)
+	}
+	if item.Ping <= 0 {
+		t.Errorf("Ping = %v", item.Ping)
+	}
+}
+
+func TestClientInfoWithoutExtraData(t *testing.T) {
+	full := testInfoResponse()
+	short := full[:bytes.Index(full, []byte("1.38.7.9\x00"))+len("1.38.7.9\x00")]
+	r := newResponder(t, func([]byte) [][]byte { return [][]byte{short} })
+
+	info, err := new(Client).Info(context.Background(), r.addr())
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	if info.Addr.ConnectionPort != info.Addr.QueryPort || info.SteamID != 0 || info.Tags != nil {
+		t.Errorf("info = %+v", info)
+	}
+}
+
+func TestClientPlayersSplit(t *testing.T) {
+	packet := appendHeader(nil, typePlayerResponse)
+	packet = append(packet, 40)
+	for i := range 40 {
+		packet = append(packet, 0)
+		packet = append(packet, fmt.Sprintf("player %02d\x00", i)...)
+		packet = binary.LittleEndian.AppendUint32(packet, uint32(int32(i-1)))
+		packet = binary.LittleEndian.AppendUint32(packet, math.Float32bits(float32(i)+0.5))
+	}
+	r := newResponder(t, challenged(typePlayerRequest, func() [][]byte {
+		return splitPackets(7, packet, 3, 0, 0)
+	}))
+
+	players, err := new(Client).Players(context.Background(), r.addr())
+	if err != nil {
+		t.Fatalf("Players: %v", err)
+	}
+	if len(players) != 40 {
+		t.Fatalf("got %d players", len(players))
+	}
+	want := steamworks.ServerPlayer{Name: "player 39", Score: 38, TimePlayed: 39500 * time.Millisecond}
+	if players[39] != want {
+		t.Errorf("players[39] = %+v, want %+v", players[39], want)
+	}
+	if players[0].Score != -1 {
+		t.Errorf("players[0].Score = %d", players[0].Score)
+	}
+}
+
+// compressedRules is testRulesPacket compressed with bzip2, since the standard
+// library only decompresses.
+const compressedRules = "425a683931415926535904e97b1100012fdd80c00000407fe00200220413000000b000e4c1543f2a37ef54a83101a9a9ffa95401a068253f555034191a34fddaafbf8da33cfa0000000492492400000001af799995555beb5d357776164d4b6d0b26a5b6859352db40000000006db6c9eb8926a71fb33333330000000000000000026565faacba55973ab2e2acb9559718fe2ee48a70a12009d2f622"
+
+func testRulesPacket() []byte {
+	b := appendHeader(nil, typeRulesResponse)
+	b = binary.LittleEndian.AppendUint16(b, 40)
+	for i := range 40 {
+		b = append(b, fmt.Sprintf("rule%02d\x00value%02d\x00", i, i)...)
+	}
+	return b
+}
+
+func TestClientRulesCompressed(t *testing.T) {
+	compressed, err := hex.DecodeString(compressedRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packet := testRulesPacket()
+	crc := crc32.ChecksumIEEE(packet)
+	r := newResponder(t, challenged(typeRulesRequest, func() [][]byte {
+		return splitPackets(-0x7ffffff0, compressed, 2, len(packet), crc)
+	}))
+
+	rules, err := new(Client).Rules(context.Background(), r.addr())
+	if err != nil {
+		t.Fatalf("Rules: %v", err)
+	}
+	if len(rules) != 40 || rules["rule17"] != "value17" {
+		t.Errorf("rules = %v", rules)
+	}
+
+	for _, tt := range []struct {
+		name string
+		size int
+		crc  uint32
+		// msg tells size rejections, made before decompressing, apart from
+		// checksum mismatches.
+		msg string
+	}{
+		{"bad checksum", len(packet), crc + 1, "checksum"},
+		{"oversized claimed size", math.MaxInt32, crc, "claims"},
+		{"negative claimed size", -1, crc, "claims"},
+	} {
+		bad := newResponder(t, challenged(typeRulesRequest, func() [][]byte {
+			return splitPackets(-0x7ffffff0, compressed, 2, tt.size, tt.crc)
+		}))
+		_, err := new(Client).Rules(context.Background(), bad.addr())
+		if !errors.Is(err, ErrMalformed) || !strings.Contains(err.Error(), tt.msg) {
+			t.Errorf("%s: err = %v, want ErrMalformed mentioning %q", tt.name, err, tt.msg)
+		}
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		handle func([]byte) [][]byte
+		want   error
+	}{
+		{
+			name:   "timeout",
+			handle: func([]byte) [][]byte { return nil },
+			want:   context.DeadlineExceeded,
+		},
+		{
+			name:   "endless challenges",
+			handle: func([]byte) [][]byte { return [][]byte{challengePacket()} },
+			want:   ErrTooManyHops,
+		},
+		{
+			name:   "wrong type",
+			handle: func([]byte) [][]byte { return [][]byte{appendHeader(nil, typeRulesResponse)} },
+			want:   ErrUnexpected,
+		},
+		{
+			name: "truncated",
+			handle: func([]byte) [][]byte {
+				full := testInfoResponse()
+				return [][]byte{full[:20]}
+			},
+			want: ErrMalformed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newResponder(t, tt.handle)
+			c := Client{Timeout: 100 * time.Millisecond}
+			if _, err := c.Info(context.Background(), r.addr()); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientContextCanceled(t *testing.T) {
+	r := newResponder(t, func([]byte) [][]byte { return nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := new(Client).Players(ctx, r.addr()); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestServerVersion(t *testing.T) {
+	for version, want := range map[string]int{"1.0.2.3": 1023, "12": 12, "": 0, "v1": 0} {
+		if got := serverVersion(version); got != want {
+			t.Errorf("serverVersion(%q) = %d, want %d", version, got, want)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package a2s
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"strings"
+)
+
+// reader decodes the little-endian fields of a packet. Reading past the end
+// sets err and returns zero values.
+type reader struct {
+	b   []byte
+	err error
+}
+
+func (r *reader) take(n int) []byte {
+	if r.err != nil || len(r.b) < n {
+		r.err = ErrMalformed
+		return make([]byte, n)
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *reader) uint8() byte    { return r.take(1)[0] }
+func (r *reader) uint16() uint16 { return binary.LittleEndian.Uint16(r.take(2)) }
+func (r *reader) int32() int32   { return int32(binary.LittleEndian.Uint32(r.take(4))) }
+func (r *reader) uint64() uint64 { return binary.LittleEndian.Uint64(r.take(8)) }
+func (r *reader) float32() float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(r.take(4)))
+}
+
+func (r *reader) string() string {
+	if r.err != nil {
+		return ""
+	}
+	i := bytes.IndexByte(r.b, 0)
+	if i < 0 {
+		r.err = ErrMalformed
+		return ""
+	}
+	s := string(r.b[:i])
+	r.b = r.b[i+1:]
+	return s
+}
+
+// splitPacket is one fragment of a response split across datagrams, in the
+// Source engine format.
+type splitPacket struct {
+	id         int32
+	total      int
+	number     int
+	compressed bool
+	// size and crc describe the decompressed payload; they are only sent in
+	// the first fragment of a compressed response.
+	size    int
+	crc     uint32
+	payload []byte
+}
+
+func parseSplitPacket(b []byte) (splitPacket, error) {
+	r := &reader{b: b}
+	p := splitPacket{id: r.int32()}
+	p.compressed = uint32(p.id)&0x80000000 != 0
+	p.total = int(r.uint8())
+	p.number = int(r.uint8())
+	r.uint16() // maximum packet size
+	if p.compressed && p.number == 0 {
+		p.size = int(r.int32())
+		p.crc = uint32(r.int32())
+	}
+	if r.err != nil || p.total == 0 || p.number >= p.total {
+		return splitPacket{}, ErrMalformed
+	}
+	p.payload = r.b
+	return p, nil
+}
+
+// maxResponseSize bounds the size a compressed split response may claim,
+// since the server controls it: 255 fragments of the largest packet.
+const maxResponseSize = 255 * maxPacketSize
+
+// splitAssembler collects the fragments of one split response.
+type splitAssembler struct {
+	parts [][]byte
+	first splitPacket
+	have  int
+}
+
+// add stores p and returns the reassembled packet, including its simple
+// header, once every fragment has arrived.
+func (a *splitAssembler) add(p splitPacket) ([]byte, bool, error) {
+	if a.parts == nil {
+		a.parts = make([][]byte, p.total)
+		a.first.id = p.id
+	}
+	if p.id != a.first.id || p.total != len(a.parts) {
+		return nil, false, fmt.Errorf("%w: fragment of another response", ErrMalformed)
+	}
+	if a.parts[p.number] == nil {
+		a.have++
+	}
+	a.parts[p.number] = p.payload
+	if p.number == 0 {
+		a.first = p
+	}
+	if a.have < len(a.parts) {
+		return nil, false, nil
+	}
+	data := bytes.Join(a.parts, nil)
+	if a.first.compressed {
+		if a.first.size < 0 || a.first.size > maxResponseSize {
+			return nil, false, fmt.Errorf("%w: compressed response claims %d bytes", ErrMalformed, a.first.size)
+		}
+		out, err := io.ReadAll(io.LimitReader(bzip2.NewReader(bytes.NewReader(data)), int64(a.first.size)+1))
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+		if len(out) != a.first.size || crc32.ChecksumIEEE(out) != a.first.crc {
+			return nil, false, fmt.Errorf("%w: decompressed size or checksum mismatch", ErrMalformed)
+		}
+		data = out
+	}
+	return data, true, nil
+}
+
+// appendString appends s as a NUL-terminated string, cut at any NUL it
+// contains.
+func appendString(b []byte, s string) []byte {
+	s, _, _ = strings.Cut(s, "\x00")
+	return append(append(b, s...), 0)
+}
+
+func appendFloat32(b []byte, v float32) []byte {
+	return binary.LittleEndian.AppendUint32(b, math.Float32bits(v))
+}
+
+// splitPayloadMax is how much of a packet each split fragment carries: the
+// maximum packet size less the split header.
+const splitPayloadMax = maxPacketSize - 12
+
+// splitResponse splits packet, including its simple header, into
+// uncompressed fragments tagged id. It returns nil if packet needs more than
+// 255 fragments.
+func splitResponse(id int32, packet []byte) [][]byte {
+	total := (len(packet) + splitPayloadMax - 1) / splitPayloadMax
+	if total > 255 {
+		return nil
+	}
+	out := make([][]byte, 0, total)
+	for i := range total {
+		part := packet[i*splitPayloadMax : min((i+1)*splitPayloadMax, len(packet))]
+		b := make([]byte, 0, 12+len(part))
+		b = append(b, 0xFE, 0xFF, 0xFF, 0xFF)
+		b = binary.LittleEndian.AppendUint32(b, uint32(id&0x7FFFFFFF))
+		b = append(b, byte(total), byte(i))
+		b = binary.LittleEndian.AppendUint16(b, maxPacketSize)
+		out = append(out, append(b, part...))
+	}
+	return out
+}
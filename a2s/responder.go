@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package a2s
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// Responder answers A2S_INFO, A2S_PLAYER and A2S_RULES queries from a
+// ServerInfo, for servers that are not logged on to Steam, such as LAN or
+// offline dedicated servers. Every query must first fetch a challenge, which
+// keeps the responder from being used to amplify spoofed traffic.
+type Responder struct {
+	info   *ServerInfo
+	secret [32]byte
+	split  atomic.Int32
+}
+
+// NewResponder returns a Responder serving info.
+func NewResponder(info *ServerInfo) *Responder {
+	r := &Responder{info: info}
+	rand.Read(r.secret[:])
+	return r
+}
+
+// Serve answers queries arriving on conn until reading from it fails, such as
+// when conn is closed, and returns that error. Packets that are not queries
+// are ignored.
+func (r *Responder) Serve(conn net.PacketConn) error {
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+		r.HandlePacket(conn, buf[:n], addr)
+	}
+}
+
+// HandlePacket answers packet if it is a query from addr, writing the reply
+// to conn, and reports whether it was one. Servers that read their game
+// socket themselves pass it every datagram starting with 0xFFFFFFFF.
+func (r *Responder) HandlePacket(conn net.PacketConn, packet []byte, addr net.Addr) bool {
+	if len(packet) < 5 || !bytes.HasPrefix(packet, []byte{0xFF, 0xFF, 0xFF, 0xFF}) {
+		return false
+	}
+	typ, body := packet[4], packet[5:]
+	switch typ {
+	case typeInfoRequest:
+		if !bytes.HasPrefix(body, []byte(infoPayload)) {
+			return false
+		}
+		body = body[len(infoPayload):]
+	case typePlayerRequest, typeRulesRequest:
+	default:
+		return false
+	}
+
+	want := r.challenge(addr)
+	if len(body) < 4 || int32(binary.LittleEndian.Uint32(body)) != want {
+		reply := binary.LittleEndian.AppendUint32(appendHeader(nil, typeChallenge), uint32(want))
+		conn.WriteTo(reply, addr)
+		return true
+	}
+
+	var reply []byte
+	switch typ {
+	case typeInfoRequest:
+		reply = r.info.appendInfo(appendHeader(nil, typeInfoResponse))
+	case typePlayerRequest:
+		reply = r.info.appendPlayers(appendHeader(nil, typePlayerResponse), time.Now())
+	case typeRulesRequest:
+		reply = r.info.appendRules(appendHeader(nil, typeRulesResponse))
+	}
+	if len(reply) <= maxPacketSize {
+		conn.WriteTo(reply, addr)
+		return true
+	}
+	for _, part := range splitResponse(r.split.Add(1), reply) {
+		conn.WriteTo(part, addr)
+	}
+	return true
+}
+
+// challenge derives the challenge number of addr from the responder's secret,
+// so no per-client state is kept.
+func (r *Responder) challenge(addr net.Addr) int32 {
+	mac := hmac.New(sha256.New, r.secret[:])
+	mac.Write([]byte(addr.String()))
+	c := int32(binary.LittleEndian.Uint32(mac.Sum(nil)))
+	if c == noChallenge {
+		c = 0
+	}
+	return c
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package a2s
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/badhex/go-steamworks"
+)
+
+func serveResponder(t *testing.T, info *ServerInfo) (string, *Responder) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	r := NewResponder(info)
+	go r.Serve(conn)
+	return conn.LocalAddr().String(), r
+}
+
+func TestResponderRoundTrip(t *testing.T) {
+	info := NewServerInfo(480)
+	info.InitGameServer(0, 0, 27015, 27016, 0, "1.2.3")
+	info.SetServerName("LAN Server")
+	info.SetMapName("arena")
+	info.SetModDir("spacewar")
+	info.SetGameDescription("Spacewar")
+	info.SetDedicatedServer(true)
+	info.SetMaxPlayerCount(8)
+	info.SetBotPlayerCount(1)
+	info.SetPasswordProtected(true)
+	info.SetGameTags("ctf,lan")
+	info.SetSpectatorPort(27020)
+	info.SetSpectatorServerName("tv")
+	info.BUpdateUserData(1, "alice", 10)
+	info.BUpdateUserData(2, "bob", 3)
+	info.BUpdateUserData(1, "alice", 12)
+	addr, _ := serveResponder(t, info)
+
+	var c Client
+	got, err := c.Info(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Info: %v", err)
+	}
+	item := got.GameServerItem
+	if item.Name != "LAN Server" || item.Map != "arena" || item.GameDir != "spacewar" || item.GameDescription != "Spacewar" {
+		t.Errorf("strings = %q %q %q %q", item.Name, item.Map, item.GameDir, item.GameDescription)
+	}
+	if item.AppID != 480 || got.GameID != 480 || item.Players != 2 || item.MaxPlayers != 8 || item.BotPlayers != 1 {
+		t.Errorf("counts = %d %d %d/%d %d", item.AppID, got.GameID, item.Players, item.MaxPlayers, item.BotPlayers)
+	}
+	if !item.Password || item.Secure || got.ServerType != ServerTypeDedicated || got.Environment != environment() {
+		t.Errorf("flags = %v %v %c %c", item.Password, item.Secure, got.ServerType, got.Environment)
+	}
+	if got.Version != "1.2.3" || item.ServerVersion != 123 || got.Protocol != protocolVersion {
+		t.Errorf("version = %q %d %d", got.Version, item.ServerVersion, got.Protocol)
+	}
+	if item.Addr.ConnectionPort != 27015 || got.SourceTVPort != 27020 || got.SourceTVName != "tv" {
+		t.Errorf("ports = %d %d %q", item.Addr.ConnectionPort, got.SourceTVPort, got.SourceTVName)
+	}
+	if !slices.Equal(item.Tags, []string{"ctf", "lan"}) {
+		t.Errorf("Tags = %q", item.Tags)
+	}
+
+	players, err := c.Players(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Players: %v", err)
+	}
+	if len(players) != 2 || players[0].Name != "alice" || players[0].Score != 12 || players[1].Name != "bob" {
+		t.Errorf("players = %+v", players)
+	}
+
+	info.SendUserDisconnect(1)
+	players, err = c.Players(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Players: %v", err)
+	}
+	if len(players) != 1 || players[0].Name != "bob" {
+		t.Errorf("players after disconnect = %+v", players)
+	}
+}
+
+func TestResponderSplitsLargeRules(t *testing.T) {
+	info := NewServerInfo(480)
+	want := make(map[string]string)
+	for i := range 200 {
+		key, value := fmt.Sprintf("rule%03d", i), strings.Repeat("v", 20)
+		info.SetKeyValue(key, value)
+		want[key] = value
+	}
+	info.SetKeyValue("removed", "x")
+	info.SetKeyValue("removed", "")
+	addr, _ := serveResponder(t, info)
+
+	rules, err := new(Client).Rules(context.Background(), addr)
+	if err != nil {
+		t.Fatalf("Rules: %v", err)
+	}
+	if len(rules) != len(want) || rules["rule199"] != want["rule199"] {
+		t.Errorf("got %d rules, want %d", len(rules), len(want))
+	}
+
+	info.ClearAllKeyValues()
+	if rules, err := new(Client).Rules(context.Background(), addr); err != nil || len(rules) != 0 {
+		t.Errorf("after ClearAllKeyValues: %v, %v", rules, err)
+	}
+}
+
+type recordingPacketConn struct {
+	net.PacketConn
+	written [][]byte
+}
+
+func (c *recordingPacketConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	c.written = append(c.written, slices.Clone(p))
+	return len(p), nil
+}
+
+func TestResponderHandlePacket(t *testing.T) {
+	r := NewResponder(NewServerInfo(480))
+	from := &net.UDPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 27005}
+	tests := []struct {
+		name    string
+		packet  []byte
+		handled bool
+	}{
+		{"game datagram", []byte("hello"), false},
+		{"short", []byte{0xFF, 0xFF, 0xFF, 0xFF}, false},
+		{"unknown type", append(appendHeader(nil, 'q'), 1, 2, 3, 4), false},
+		{"info without payload", appendHeader(nil, typeInfoRequest), false},
+		{"info", append(appendHeader(nil, typeInfoRequest), infoPayload...), true},
+		{"players", challengeRequest(typePlayerRequest)(noChallenge), true},
+		{"rules with wrong challenge", challengeRequest(typeRulesRequest)(r.challenge(from) + 1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &recordingPacketConn{}
+			if got := r.HandlePacket(conn, tt.packet, from); got != tt.handled {
+				t.Fatalf("HandlePacket = %v, want %v", got, tt.handled)
+			}
+			if !tt.handled {
+				if len(conn.written) != 0 {
+					t.Errorf("wrote %d packets for an ignored datagram", len(conn.written))
+				}
+				return
+			}
+			if len(conn.written) != 1 || len(conn.written[0]) != 9 || conn.written[0][4] != typeChallenge {
+				t.Errorf("wrote %q, want a challenge", conn.written)
+			}
+		})
+	}
+}
+
+type fakeGameServer struct {
+	steamworks.ISteamGameServer
+	calls []string
+}
+
+func (f *fakeGameServer) SetServerName(name string) { f.calls = append(f.calls, "SetServerName "+name) }
+func (f *fakeGameServer) SetKeyValue(key, value string) {
+	f.calls = append(f.calls, "SetKeyValue "+key+"="+value)
+}
+func (f *fakeGameServer) BUpdateUserData(id steamworks.CSteamID, name string, score uint32) bool {
+	f.calls = append(f.calls, fmt.Sprintf("BUpdateUserData %d %s %d", id, name, score))
+	return false
+}
+
+func TestServerInfoMirror(t *testing.T) {
+	info := NewServerInfo(480)
+	gs := &fakeGameServer{}
+	mirrored := info.Mirror(gs)
+	mirrored.SetServerName("mirrored")
+	mirrored.SetKeyValue("mode", "ctf")
+	if mirrored.BUpdateUserData(7, "carol", 5) {
+		t.Error("BUpdateUserData did not return Steam's result")
+	}
+
+	want := []string{"SetServerName mirrored", "SetKeyValue mode=ctf", "BUpdateUserData 7 carol 5"}
+	if !slices.Equal(gs.calls, want) {
+		t.Errorf("forwarded calls = %q, want %q", gs.calls, want)
+	}
+	if info.name != "mirrored" || info.rules["mode"] != "ctf" || len(info.players) != 1 || info.players[0].name != "carol" {
+		t.Errorf("ServerInfo = %+v", info)
+	}
+}
@@ -0,0 +1,370 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package a2s
+
+import (
+	"encoding/binary"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/badhex/go-steamworks"
+)
+
+// protocolVersion is the protocol number reported in A2S_INFO responses.
+const protocolVersion = 17
+
+// ServerInfo is what a game server reports to queries. Its setters match
+// those of ISteamGameServer, so a server can make the same calls whether or
+// not Steam is running; Mirror forwards them to both. It is safe for
+// concurrent use.
+type ServerInfo struct {
+	mu          sync.RWMutex
+	appID       steamworks.AppId_t
+	gamePort    uint16
+	version     string
+	name        string
+	mapName     string
+	modDir      string
+	description string
+	dedicated   bool
+	maxPlayers  int32
+	bots        int32
+	password    bool
+	tags        string
+	tvPort      uint16
+	tvName      string
+	rules       map[string]string
+	// players holds connected players in the order they were first updated.
+	players []serverPlayer
+}
+
+type serverPlayer struct {
+	steamID steamworks.CSteamID
+	name    string
+	score   uint32
+	joined  time.Time
+}
+
+// NewServerInfo returns an empty ServerInfo for a server of appID.
+func NewServerInfo(appID steamworks.AppId_t) *ServerInfo {
+	return &ServerInfo{appID: appID, rules: make(map[string]string)}
+}
+
+// InitGameServer records the game port and version string. The other
+// arguments exist to match ISteamGameServer and are ignored.
+func (s *ServerInfo) InitGameServer(ip uint32, steamPort, gamePort, queryPort uint16, serverMode uint32, versionString string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gamePort = gamePort
+	s.version = versionString
+	return true
+}
+
+func (s *ServerInfo) SetServerName(serverName string) {
+	s.set(&s.name, serverName)
+}
+
+func (s *ServerInfo) SetMapName(mapName string) {
+	s.set(&s.mapName, mapName)
+}
+
+// SetModDir sets the game directory, reported as the folder.
+func (s *ServerInfo) SetModDir(modDir string) {
+	s.set(&s.modDir, modDir)
+}
+
+func (s *ServerInfo) SetGameDescription(description string) {
+	s.set(&s.description, description)
+}
+
+// SetGameTags sets the comma-separated tags, reported as keywords.
+func (s *ServerInfo) SetGameTags(gameTags string) {
+	s.set(&s.tags, gameTags)
+}
+
+func (s *ServerInfo) SetSpectatorServerName(spectatorServerName string) {
+	s.set(&s.tvName, spectatorServerName)
+}
+
+func (s *ServerInfo) SetSpectatorPort(spectatorPort uint16) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tvPort = spectatorPort
+}
+
+func (s *ServerInfo) SetDedicatedServer(dedicated bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dedicated = dedicated
+}
+
+func (s *ServerInfo) SetPasswordProtected(passwordProtected bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.password = passwordProtected
+}
+
+func (s *ServerInfo) SetMaxPlayerCount(playersMax int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxPlayers = playersMax
+}
+
+func (s *ServerInfo) SetBotPlayerCount(botPlayers int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bots = botPlayers
+}
+
+// SetKeyValue sets a rule. An empty value removes it.
+func (s *ServerInfo) SetKeyValue(key string, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if value == "" {
+		delete(s.rules, key)
+		return
+	}
+	s.rules[key] = value
+}
+
+func (s *ServerInfo) ClearAllKeyValues() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.rules)
+}
+
+// BUpdateUserData adds or updates a connected player. A player's time played
+// counts from the first update.
+func (s *ServerInfo) BUpdateUserData(steamIDUser steamworks.CSteamID, playerName string, score uint32) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.player(steamIDUser); i >= 0 {
+		s.players[i].name = playerName
+		s.players[i].score = score
+		return true
+	}
+	s.players = append(s.players, serverPlayer{steamID: steamIDUser, name: playerName, score: score, joined: time.Now()})
+	return true
+}
+
+// SendUserDisconnect removes a player.
+func (s *ServerInfo) SendUserDisconnect(steamIDUser steamworks.CSteamID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.player(steamIDUser); i >= 0 {
+		s.players = slices.Delete(s.players, i, i+1)
+	}
+}
+
+// EndAuthSession removes a player, like SendUserDisconnect.
+func (s *ServerInfo) EndAuthSession(steamID steamworks.CSteamID) {
+	s.SendUserDisconnect(steamID)
+}
+
+func (s *ServerInfo) set(field *string, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*field = value
+}
+
+func (s *ServerInfo) player(steamID steamworks.CSteamID) int {
+	return slices.IndexFunc(s.players, func(p serverPlayer) bool { return p.steamID == steamID })
+}
+
+// Mirror returns an ISteamGameServer that forwards every call to gs and also
+// records the setters ServerInfo has, so one set of calls serves both Steam
+// and the Responder.
+func (s *ServerInfo) Mirror(gs steamworks.ISteamGameServer) steamworks.ISteamGameServer {
+	return &mirroredGameServer{ISteamGameServer: gs, info: s}
+}
+
+type mirroredGameServer struct {
+	steamworks.ISteamGameServer
+	info *ServerInfo
+}
+
+func (m *mirroredGameServer) InitGameServer(ip uint32, steamPort, gamePort, queryPort uint16, serverMode uint32, versionString string) bool {
+	m.info.InitGameServer(ip, steamPort, gamePort, queryPort, serverMode, versionString)
+	return m.ISteamGameServer.InitGameServer(ip, steamPort, gamePort, queryPort, serverMode, versionString)
+}
+
+func (m *mirroredGameServer) SetServerName(serverName string) {
+	m.info.SetServerName(serverName)
+	m.ISteamGameServer.SetServerName(serverName)
+}
+
+func (m *mirroredGameServer) SetMapName(mapName string) {
+	m.info.SetMapName(mapName)
+	m.ISteamGameServer.SetMapName(mapName)
+}
+
+func (m *mirroredGameServer) SetModDir(modDir string) {
+	m.info.SetModDir(modDir)
+	m.ISteamGameServer.SetModDir(modDir)
+}
+
+func (m *mirroredGameServer) SetGameDescription(description string) {
+	m.info.SetGameDescription(description)
+	m.ISteamGameServer.SetGameDescription(description)
+}
+
+func (m *mirroredGameServer) SetGameTags(gameTags string) {
+	m.info.SetGameTags(gameTags)
+	m.ISteamGameServer.SetGameTags(gameTags)
+}
+
+func (m *mirroredGameServer) SetSpectatorServerName(spectatorServerName string) {
+	m.info.SetSpectatorServerName(spectatorServerName)
+	m.ISteamGameServer.SetSpectatorServerName(spectatorServerName)
+}
+
+func (m *mirroredGameServer) SetSpectatorPort(spectatorPort uint16) {
+	m.info.SetSpectatorPort(spectatorPort)
+	m.ISteamGameServer.SetSpectatorPort(spectatorPort)
+}
+
+func (m *mirroredGameServer) SetDedicatedServer(dedicated bool) {
+	m.info.SetDedicatedServer(dedicated)
+	m.ISteamGameServer.SetDedicatedServer(dedicated)
+}
+
+func (m *mirroredGameServer) SetPasswordProtected(passwordProtected bool) {
+	m.info.SetPasswordProtected(passwordProtected)
+	m.ISteamGameServer.SetPasswordProtected(passwordProtected)
+}
+
+func (m *mirroredGameServer) SetMaxPlayerCount(playersMax int32) {
+	m.info.SetMaxPlayerCount(playersMax)
+	m.ISteamGameServer.SetMaxPlayerCount(playersMax)
+}
+
+func (m *mirroredGameServer) SetBotPlayerCount(botPlayers int32) {
+	m.info.SetBotPlayerCount(botPlayers)
+	m.ISteamGameServer.SetBotPlayerCount(botPlayers)
+}
+
+func (m *mirroredGameServer) SetKeyValue(key string, value string) {
+	m.info.SetKeyValue(key, value)
+	m.ISteamGameServer.SetKeyValue(key, value)
+}
+
+func (m *mirroredGameServer) ClearAllKeyValues() {
+	m.info.ClearAllKeyValues()
+	m.ISteamGameServer.ClearAllKeyValues()
+}
+
+func (m *mirroredGameServer) BUpdateUserData(steamIDUser steamworks.CSteamID, playerName string, score uint32) bool {
+	m.info.BUpdateUserData(steamIDUser, playerName, score)
+	return m.ISteamGameServer.BUpdateUserData(steamIDUser, playerName, score)
+}
+
+func (m *mirroredGameServer) SendUserDisconnect(steamIDUser steamworks.CSteamID) {
+	m.info.SendUserDisconnect(steamIDUser)
+	m.ISteamGameServer.SendUserDisconnect(steamIDUser)
+}
+
+func (m *mirroredGameServer) EndAuthSession(steamID steamworks.CSteamID) {
+	m.info.EndAuthSession(steamID)
+	m.ISteamGameServer.EndAuthSession(steamID)
+}
+
+// appendInfo appends the body of an A2S_INFO response.
+func (s *ServerInfo) appendInfo(b []byte) []byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b = append(b, protocolVersion)
+	b = appendString(b, s.name)
+	b = appendString(b, s.mapName)
+	b = appendString(b, s.modDir)
+	b = appendString(b, s.description)
+	b = binary.LittleEndian.AppendUint16(b, uint16(s.appID))
+	b = append(b, clampByte(len(s.players)), clampByte(int(s.maxPlayers)), clampByte(int(s.bots)))
+	serverType := ServerTypeNonDedicated
+	if s.dedicated {
+		serverType = ServerTypeDedicated
+	}
+	b = append(b, serverType, environment(), boolByte(s.password), 0)
+	b = appendString(b, s.version)
+
+	edf := byte(edfGameID)
+	if s.gamePort != 0 {
+		edf |= edfPort
+	}
+	if s.tvPort != 0 {
+		edf |= edfSourceTV
+	}
+	if s.tags != "" {
+		edf |= edfKeywords
+	}
+	b = append(b, edf)
+	if edf&edfPort != 0 {
+		b = binary.LittleEndian.AppendUint16(b, s.gamePort)
+	}
+	if edf&edfSourceTV != 0 {
+		b = binary.LittleEndian.AppendUint16(b, s.tvPort)
+		b = appendString(b, s.tvName)
+	}
+	if edf&edfKeywords != 0 {
+		b = appendString(b, s.tags)
+	}
+	return binary.LittleEndian.AppendUint64(b, uint64(s.appID))
+}
+
+// appendPlayers appends the body of an A2S_PLAYER response, with at most 255
+// players.
+func (s *ServerInfo) appendPlayers(b []byte, now time.Time) []byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	players := s.players[:min(len(s.players), 255)]
+	b = append(b, byte(len(players)))
+	for _, p := range players {
+		b = append(b, 0)
+		b = appendString(b, p.name)
+		b = binary.LittleEndian.AppendUint32(b, p.score)
+		b = appendFloat32(b, float32(now.Sub(p.joined).Seconds()))
+	}
+	return b
+}
+
+// appendRules appends the body of an A2S_RULES response, sorted by key.
+func (s *ServerInfo) appendRules(b []byte) []byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]string, 0, len(s.rules))
+	for k := range s.rules {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	keys = keys[:min(len(keys), 0xFFFF)]
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(keys)))
+	for _, k := range keys {
+		b = appendString(b, k)
+		b = appendString(b, s.rules[k])
+	}
+	return b
+}
+
+func clampByte(n int) byte {
+	return byte(max(0, min(n, 255)))
+}
+
+func boolByte(v bool) byte {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func environment() byte {
+	switch runtime.GOOS {
+	case "windows":
+		return EnvironmentWindows
+	case "darwin":
+		return EnvironmentMac
+	default:
+		return EnvironmentLinux
+	}
+}
@@ -90,6 +90,39 @@ var (
 	ptrAPI_ISteamFriends_ActivateGameOverlayToStore                   func(uintptr, AppId_t, EOverlayToStoreFlag)
 	ptrAPI_ISteamFriends_ActivateGameOverlayInviteDialog              func(uintptr, CSteamID)
 	ptrAPI_ISteamFriends_ActivateGameOverlayInviteDialogConnectString func(uintptr, string)
+	ptrAPI_ISteamFriends_ClearRichPresence                            func(uintptr)
+	ptrAPI_ISteamFriends_GetFriendRichPresence                        func(uintptr, CSteamID, string) string
+	ptrAPI_ISteamFriends_GetFriendRichPresenceKeyCount                func(uintptr, CSteamID) int32
+	ptrAPI_ISteamFriends_GetFriendRichPresenceKeyByIndex              func(uintptr, CSteamID, int32) string
+	ptrAPI_ISteamFriends_RequestFriendRichPresence                    func(uintptr, CSteamID)
+	ptrAPI_ISteamFriends_GetFriendsGroupCount                         func(uintptr) int32
+	ptrAPI_ISteamFriends_GetFriendsGroupIDByIndex                     func(uintptr, int32) FriendsGroupID_t
+	ptrAPI_ISteamFriends_GetFriendsGroupName                          func(uintptr, FriendsGroupID_t) string
+	ptrAPI_ISteamFriends_GetFriendsGroupMembersCount                  func(uintptr, FriendsGroupID_t) int32
+	ptrAPI_ISteamFriends_GetFriendsGroupMembersList                   func(uintptr, FriendsGroupID_t, uintptr, int32)
+	ptrAPI_ISteamFriends_RequestUserInformation                       func(uintptr, CSteamID, bool) bool
+	ptrAPI_ISteamFriends_GetClanCount                                 func(uintptr) int32
+	ptrAPI_ISteamFriends_GetClanByIndex                               func(uintptr, int32) CSteamID
+	ptrAPI_ISteamFriends_GetClanName                                  func(uintptr, CSteamID) string
+	ptrAPI_ISteamFriends_GetClanTag                                   func(uintptr, CSteamID) string
+	ptrAPI_ISteamFriends_GetClanActivityCounts                        func(uintptr, CSteamID, uintptr, uintptr, uintptr) bool
+	ptrAPI_ISteamFriends_DownloadClanActivityCounts                   func(uintptr, uintptr, int32) SteamAPICall_t
+	ptrAPI_ISteamFriends_GetClanOwner                                 func(uintptr, CSteamID) CSteamID
+	ptrAPI_ISteamFriends_GetClanOfficerCount                          func(uintptr, CSteamID) int32
+	ptrAPI_ISteamFriends_GetClanOfficerByIndex                        func(uintptr, CSteamID, int32) CSteamID
+	ptrAPI_ISteamFriends_RequestClanOfficerList                       func(uintptr, CSteamID) SteamAPICall_t
+	ptrAPI_ISteamFriends_IsClanPublic                                 func(uintptr, CSteamID) bool
+	ptrAPI_ISteamFriends_IsClanOfficialGameGroup                      func(uintptr, CSteamID) bool
+	ptrAPI_ISteamFriends_JoinClanChatRoom                             func(uintptr, CSteamID) SteamAPICall_t
+	ptrAPI_ISteamFriends_LeaveClanChatRoom                            func(uintptr, CSteamID) bool
+	ptrAPI_ISteamFriends_GetClanChatMemberCount                       func(uintptr, CSteamID) int32
+	ptrAPI_ISteamFriends_GetChatMemberByIndex                         func(uintptr, CSteamID, int32) CSteamID
+	ptrAPI_ISteamFriends_SendClanChatMessage                          func(uintptr, CSteamID, string) bool
+	ptrAPI_ISteamFriends_GetClanChatMessage                           func(uintptr, CSteamID, int32, uintptr, int32, uintptr, uintptr) int32
+	ptrAPI_ISteamFriends_IsClanChatAdmin                              func(uintptr, CSteamID, CSteamID) bool
+	ptrAPI_ISteamFriends_IsClanChatWindowOpenInSteam                  func(uintptr, CSteamID) bool
+	ptrAPI_ISteamFriends_OpenClanChatWindowInSteam                    func(uintptr, CSteamID) bool
+	ptrAPI_ISteamFriends_CloseClanChatWindowInSteam                   func(uintptr, CSteamID) bool
 
 	// ISteamMatchmaking
 	ptrAPI_SteamMatchmaking                                             func() uintptr
@@ -500,6 +533,39 @@ func registerFunctions(lib uintptr) {
 	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_ActivateGameOverlayToStore, lib, flatAPI_ISteamFriends_ActivateGameOverlayToStore)
 	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_ActivateGameOverlayInviteDialog, lib, flatAPI_ISteamFriends_ActivateGameOverlayInviteDialog)
 	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_ActivateGameOverlayInviteDialogConnectString, lib, flatAPI_ISteamFriends_ActivateGameOverlayInviteDialogConnectString)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_ClearRichPresence, lib, flatAPI_ISteamFriends_ClearRichPresence)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetFriendRichPresence, lib, flatAPI_ISteamFriends_GetFriendRichPresence)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetFriendRichPresenceKeyCount, lib, flatAPI_ISteamFriends_GetFriendRichPresenceKeyCount)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetFriendRichPresenceKeyByIndex, lib, flatAPI_ISteamFriends_GetFriendRichPresenceKeyByIndex)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_RequestFriendRichPresence, lib, flatAPI_ISteamFriends_RequestFriendRichPresence)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetFriendsGroupCount, lib, flatAPI_ISteamFriends_GetFriendsGroupCount)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetFriendsGroupIDByIndex, lib, flatAPI_ISteamFriends_GetFriendsGroupIDByIndex)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetFriendsGroupName, lib, flatAPI_ISteamFriends_GetFriendsGroupName)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetFriendsGroupMembersCount, lib, flatAPI_ISteamFriends_GetFriendsGroupMembersCount)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetFriendsGroupMembersList, lib, flatAPI_ISteamFriends_GetFriendsGroupMembersList)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_RequestUserInformation, lib, flatAPI_ISteamFriends_RequestUserInformation)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetClanCount, lib, flatAPI_ISteamFriends_GetClanCount)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetClanByIndex, lib, flatAPI_ISteamFriends_GetClanByIndex)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetClanName, lib, flatAPI_ISteamFriends_GetClanName)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetClanTag, lib, flatAPI_ISteamFriends_GetClanTag)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetClanActivityCounts, lib, flatAPI_ISteamFriends_GetClanActivityCounts)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_DownloadClanActivityCounts, lib, flatAPI_ISteamFriends_DownloadClanActivityCounts)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetClanOwner, lib, flatAPI_ISteamFriends_GetClanOwner)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetClanOfficerCount, lib, flatAPI_ISteamFriends_GetClanOfficerCount)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetClanOfficerByIndex, lib, flatAPI_ISteamFriends_GetClanOfficerByIndex)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_RequestClanOfficerList, lib, flatAPI_ISteamFriends_RequestClanOfficerList)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_IsClanPublic, lib, flatAPI_ISteamFriends_IsClanPublic)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_IsClanOfficialGameGroup, lib, flatAPI_ISteamFriends_IsClanOfficialGameGroup)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_JoinClanChatRoom, lib, flatAPI_ISteamFriends_JoinClanChatRoom)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_LeaveClanChatRoom, lib, flatAPI_ISteamFriends_LeaveClanChatRoom)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetClanChatMemberCount, lib, flatAPI_ISteamFriends_GetClanChatMemberCount)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetChatMemberByIndex, lib, flatAPI_ISteamFriends_GetChatMemberByIndex)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_SendClanChatMessage, lib, flatAPI_ISteamFriends_SendClanChatMessage)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_GetClanChatMessage, lib, flatAPI_ISteamFriends_GetClanChatMessage)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_IsClanChatAdmin, lib, flatAPI_ISteamFriends_IsClanChatAdmin)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_IsClanChatWindowOpenInSteam, lib, flatAPI_ISteamFriends_IsClanChatWindowOpenInSteam)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_OpenClanChatWindowInSteam, lib, flatAPI_ISteamFriends_OpenClanChatWindowInSteam)
+	purego.RegisterLibFunc(&ptrAPI_ISteamFriends_CloseClanChatWindowInSteam, lib, flatAPI_ISteamFriends_CloseClanChatWindowInSteam)
 
 	// ISteamMatchmaking
 	purego.RegisterLibFunc(&ptrAPI_SteamMatchmaking, lib, flatAPI_SteamMatchmaking)
@@ -1266,6 +1332,10 @@ func (s steamApps) GetBetaInfo(index int) (flags uint32, buildID uint32, lastUpd
 	return flags, buildID, lastUpdated, cStringToGo(nameBuf[:]), cStringToGo(descBuf[:]), true
 }
 
+func (s steamApps) Betas() iter.Seq[Beta] {
+	return betas(s)
+}
+
 func (s steamApps) InstallDLC(appID AppId_t) {
 	ptrAPI_ISteamApps_InstallDLC(uintptr(s), appID)
 }
@@ -1365,10 +1435,100 @@ func (s steamFriends) GetLargeFriendAvatar(friend CSteamID) int32 {
 	return ptrAPI_ISteamFriends_GetLargeFriendAvatar(uintptr(s), friend)
 }
 
+func (s steamFriends) RequestUserInformation(user CSteamID, requireNameOnly bool) bool {
+	return ptrAPI_ISteamFriends_RequestUserInformation(uintptr(s), user, requireNameOnly)
+}
+
 func (s steamFriends) SetRichPresence(key, value string) bool {
 	return ptrAPI_ISteamFriends_SetRichPresence(uintptr(s), key, value)
 }
 
+func (s steamFriends) ClearRichPresence() {
+	ptrAPI_ISteamFriends_ClearRichPresence(uintptr(s))
+}
+
+func (s steamFriends) GetFriendRichPresence(friend CSteamID, key string) string {
+	return unique.Make(ptrAPI_ISteamFriends_GetFriendRichPresence(uintptr(s), friend, key)).Value()
+}
+
+func (s steamFriends) GetFriendRichPresenceKeyCount(friend CSteamID) int {
+	return int(ptrAPI_ISteamFriends_GetFriendRichPresenceKeyCount(uintptr(s), friend))
+}
+
+func (s steamFriends) GetFriendRichPresenceKeyByIndex(friend CSteamID, index int) string {
+	return unique.Make(ptrAPI_ISteamFriends_GetFriendRichPresenceKeyByIndex(uintptr(s), friend, int32(index))).Value()
+}
+
+func (s steamFriends) FriendRichPresence(friend CSteamID) iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		count := s.GetFriendRichPresenceKeyCount(friend)
+		for i := 0; i < count; i++ {
+			key := s.GetFriendRichPresenceKeyByIndex(friend, i)
+			if key == "" {
+				continue
+			}
+			if !yield(key, s.GetFriendRichPresence(friend, key)) {
+				return
+			}
+		}
+	}
+}
+
+func (s steamFriends) RequestFriendRichPresence(friend CSteamID) {
+	ptrAPI_ISteamFriends_RequestFriendRichPresence(uintptr(s), friend)
+}
+
+func (s steamFriends) GetFriendsGroupCount() int {
+	return int(ptrAPI_ISteamFriends_GetFriendsGroupCount(uintptr(s)))
+}
+
+func (s steamFriends) GetFriendsGroupIDByIndex(index int) FriendsGroupID_t {
+	return ptrAPI_ISteamFriends_GetFriendsGroupIDByIndex(uintptr(s), int32(index))
+}
+
+func (s steamFriends) FriendsGroups() iter.Seq[FriendsGroupID_t] {
+	return func(yield func(FriendsGroupID_t) bool) {
+		count := s.GetFriendsGroupCount()
+		for i := 0; i < count; i++ {
+			groupID := s.GetFriendsGroupIDByIndex(i)
+			if groupID == FriendsGroupIDInvalid {
+				continue
+			}
+			if !yield(groupID) {
+				return
+			}
+		}
+	}
+}
+
+func (s steamFriends) GetFriendsGroupName(groupID FriendsGroupID_t) string {
+	return unique.Make(ptrAPI_ISteamFriends_GetFriendsGroupName(uintptr(s), groupID)).Value()
+}
+
+func (s steamFriends) GetFriendsGroupMembersCount(groupID FriendsGroupID_t) int {
+	return int(ptrAPI_ISteamFriends_GetFriendsGroupMembersCount(uintptr(s), groupID))
+}
+
+func (s steamFriends) GetFriendsGroupMembersList(groupID FriendsGroupID_t) []CSteamID {
+	count := s.GetFriendsGroupMembersCount(groupID)
+	if count <= 0 {
+		return nil
+	}
+	members := make([]CSteamID, count)
+	ptrAPI_ISteamFriends_GetFriendsGroupMembersList(uintptr(s), groupID, uintptr(unsafe.Pointer(&members[0])), int32(count))
+	return members
+}
+
+func (s steamFriends) FriendsGroupMembers(groupID FriendsGroupID_t) iter.Seq[CSteamID] {
+	return func(yield func(CSteamID) bool) {
+		for _, member := range s.GetFriendsGroupMembersList(groupID) {
+			if !yield(member) {
+				return
+			}
+		}
+	}
+}
+
 func (s steamFriends) GetFriendGamePlayed(friend CSteamID) (FriendGameInfo, bool) {
 	var info FriendGameInfo
 	ok := ptrAPI_ISteamFriends_GetFriendGamePlayed(uintptr(s), friend, uintptr(unsafe.Pointer(&info)))
@@ -1379,6 +1539,153 @@ func (s steamFriends) InviteUserToGame(friend CSteamID, connectString string) bo
 	return ptrAPI_ISteamFriends_InviteUserToGame(uintptr(s), friend, connectString)
 }
 
+func (s steamFriends) GetClanCount() int {
+	return int(ptrAPI_ISteamFriends_GetClanCount(uintptr(s)))
+}
+
+func (s steamFriends) GetClanByIndex(index int) CSteamID {
+	return ptrAPI_ISteamFriends_GetClanByIndex(uintptr(s), int32(index))
+}
+
+func (s steamFriends) Clans() iter.Seq[CSteamID] {
+	return func(yield func(CSteamID) bool) {
+		count := s.GetClanCount()
+		for i := 0; i < count; i++ {
+			if !yield(s.GetClanByIndex(i)) {
+				return
+			}
+		}
+	}
+}
+
+func (s steamFriends) GetClanName(clanID CSteamID) string {
+	return unique.Make(ptrAPI_ISteamFriends_GetClanName(uintptr(s), clanID)).Value()
+}
+
+func (s steamFriends) GetClanTag(clanID CSteamID) string {
+	return unique.Make(ptrAPI_ISteamFriends_GetClanTag(uintptr(s), clanID)).Value()
+}
+
+func (s steamFriends) GetClanActivityCounts(clanID CSteamID) (online, inGame, chatting int, ok bool) {
+	var rawOnline, rawInGame, rawChatting int32
+	ok = ptrAPI_ISteamFriends_GetClanActivityCounts(
+		uintptr(s),
+		clanID,
+		uintptr(unsafe.Pointer(&rawOnline)),
+		uintptr(unsafe.Pointer(&rawInGame)),
+		uintptr(unsafe.Pointer(&rawChatting)),
+	)
+	return int(rawOnline), int(rawInGame), int(rawChatting), ok
+}
+
+func (s steamFriends) DownloadClanActivityCounts(clanIDs []CSteamID) SteamAPICall_t {
+	if len(clanIDs) == 0 {
+		return 0
+	}
+	return ptrAPI_ISteamFriends_DownloadClanActivityCounts(uintptr(s), uintptr(unsafe.Pointer(&clanIDs[0])), int32(len(clanIDs)))
+}
+
+func (s steamFriends) GetClanOwner(clanID CSteamID) CSteamID {
+	return ptrAPI_ISteamFriends_GetClanOwner(uintptr(s), clanID)
+}
+
+func (s steamFriends) RequestClanOfficerList(clanID CSteamID) SteamAPICall_t {
+	return ptrAPI_ISteamFriends_RequestClanOfficerList(uintptr(s), clanID)
+}
+
+func (s steamFriends) GetClanOfficerCount(clanID CSteamID) int {
+	return int(ptrAPI_ISteamFriends_GetClanOfficerCount(uintptr(s), clanID))
+}
+
+func (s steamFriends) GetClanOfficerByIndex(clanID CSteamID, index int) CSteamID {
+	return ptrAPI_ISteamFriends_GetClanOfficerByIndex(uintptr(s), clanID, int32(index))
+}
+
+func (s steamFriends) ClanOfficers(clanID CSteamID) iter.Seq[CSteamID] {
+	return func(yield func(CSteamID) bool) {
+		count := s.GetClanOfficerCount(clanID)
+		for i := 0; i < count; i++ {
+			if !yield(s.GetClanOfficerByIndex(clanID, i)) {
+				return
+			}
+		}
+	}
+}
+
+func (s steamFriends) IsClanPublic(clanID CSteamID) bool {
+	return ptrAPI_ISteamFriends_IsClanPublic(uintptr(s), clanID)
+}
+
+func (s steamFriends) IsClanOfficialGameGroup(clanID CSteamID) bool {
+	return ptrAPI_ISteamFriends_IsClanOfficialGameGroup(uintptr(s), clanID)
+}
+
+func (s steamFriends) JoinClanChatRoom(clanID CSteamID) SteamAPICall_t {
+	return ptrAPI_ISteamFriends_JoinClanChatRoom(uintptr(s), clanID)
+}
+
+func (s steamFriends) LeaveClanChatRoom(clanChatID CSteamID) bool {
+	return ptrAPI_ISteamFriends_LeaveClanChatRoom(uintptr(s), clanChatID)
+}
+
+func (s steamFriends) GetClanChatMemberCount(clanChatID CSteamID) int {
+	return int(ptrAPI_ISteamFriends_GetClanChatMemberCount(uintptr(s), clanChatID))
+}
+
+func (s steamFriends) GetChatMemberByIndex(clanChatID CSteamID, index int) CSteamID {
+	return ptrAPI_ISteamFriends_GetChatMemberByIndex(uintptr(s), clanChatID, int32(index))
+}
+
+func (s steamFriends) ClanChatMembers(clanChatID CSteamID) iter.Seq[CSteamID] {
+	return func(yield func(CSteamID) bool) {
+		count := s.GetClanChatMemberCount(clanChatID)
+		for i := 0; i < count; i++ {
+			if !yield(s.GetChatMemberByIndex(clanChatID, i)) {
+				return
+			}
+		}
+	}
+}
+
+func (s steamFriends) SendClanChatMessage(clanChatID CSteamID, text string) bool {
+	return ptrAPI_ISteamFriends_SendClanChatMessage(uintptr(s), clanChatID, text)
+}
+
+func (s steamFriends) GetClanChatMessage(clanChatID CSteamID, messageID int, text []byte) (bytesCopied int, entryType EChatEntryType, chatter CSteamID) {
+	var ptr uintptr
+	if len(text) != 0 {
+		ptr = uintptr(unsafe.Pointer(&text[0]))
+	}
+	var rawEntryType int32
+	bytesCopied = int(ptrAPI_ISteamFriends_GetClanChatMessage(
+		uintptr(s),
+		clanChatID,
+		int32(messageID),
+		ptr,
+		int32(len(text)),
+		uintptr(unsafe.Pointer(&rawEntryType)),
+		uintptr(unsafe.Pointer(&chatter)),
+	))
+	entryType = EChatEntryType(rawEntryType)
+	return
+}
+
+func (s steamFriends) IsClanChatAdmin(clanChatID, user CSteamID) bool {
+	return ptrAPI_ISteamFriends_IsClanChatAdmin(uintptr(s), clanChatID, user)
+}
+
+func (s steamFriends) IsClanChatWindowOpenInSteam(clanChatID CSteamID) bool {
+	return ptrAPI_ISteamFriends_IsClanChatWindowOpenInSteam(uintptr(s), clanChatID)
+}
+
+func (s steamFriends) OpenClanChatWindowInSteam(clanChatID CSteamID) bool {
+	return ptrAPI_ISteamFriends_OpenClanChatWindowInSteam(uintptr(s), clanChatID)
+}
+
+func (s steamFriends) CloseClanChatWindowInSteam(clanChatID CSteamID) bool {
+	return ptrAPI_ISteamFriends_CloseClanChatWindowInSteam(uintptr(s), clanChatID)
+}
+
 func (s steamFriends) ActivateGameOverlay(dialog string) {
 	ptrAPI_ISteamFriends_ActivateGameOverlay(uintptr(s), dialog)
 }
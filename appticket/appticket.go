@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+// Package appticket decrypts and validates Steam encrypted app tickets in pure Go.
+//
+// It is a drop-in alternative to the SteamEncryptedAppTicket_* helpers exported
+// by libsdkencryptedappticket, intended for backends that must not load native
+// Steam libraries. Tickets are obtained on the client with
+// ISteamUser.GetEncryptedAppTicket and decrypted with the app's 32-byte
+// encrypted app ticket key from the Steamworks partner site.
+//
+// An encrypted ticket is a protobuf EncryptedAppTicket message whose
+// encrypted_ticket field holds, after symmetric decryption:
+//
+//	user data | ownership ticket | salt (8) | SHA-1(user data | ownership ticket | salt) (20) | trailer (8)
+//
+// The ownership ticket starts with its own little-endian uint32 length, and the
+// optional trailer carries the app-defined value set on the partner site.
+package appticket
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"slices"
+	"time"
+
+	"github.com/badhex/go-steamworks"
+)
+
+// KeyLen is the length of an encrypted app ticket symmetric key.
+const KeyLen = 32
+
+const (
+	saltLen          = 8
+	digestLen        = sha1.Size
+	trailerLen       = 8
+	ownershipMinLen  = 46
+	appDefinedMarker = 1
+)
+
+var (
+	ErrInvalidKey       = errors.New("appticket: key must be 32 bytes")
+	ErrMalformedTicket  = errors.New("appticket: malformed ticket")
+	ErrChecksumMismatch = errors.New("appticket: encrypted ticket checksum mismatch")
+	ErrDecryptFailed    = errors.New("appticket: ticket decryption failed")
+	ErrSignatureInvalid = errors.New("appticket: ticket signature mismatch")
+	ErrUnsigned         = errors.New("appticket: ticket is not signed")
+)
+
+// DLC describes a DLC app and the licenses that grant it.
+type DLC struct {
+	AppID    steamworks.AppId_t
+	Licenses []uint32
+}
+
+// Ticket is a decrypted and validated encrypted app ticket.
+type Ticket struct {
+	Version         uint32
+	SteamID         steamworks.CSteamID
+	AppID           steamworks.AppId_t
+	ExternalIP      uint32
+	InternalIP      uint32
+	OwnershipFlags  uint32
+	IssueTime       time.Time
+	ExpireTime      time.Time
+	Licenses        []uint32
+	DLC             []DLC
+	UserData        []byte
+	AppDefinedValue uint32
+	HasAppDefined   bool
+}
+
+// IsForApp reports whether the ticket was issued for appID.
+func (t *Ticket) IsForApp(appID steamworks.AppId_t) bool {
+	return t.AppID == appID
+}
+
+// OwnsApp reports whether the ticket grants ownership of appID, either as the
+// ticket's own app or as one of the DLC listed in it.
+func (t *Ticket) OwnsApp(appID steamworks.AppId_t) bool {
+	if t.AppID == appID {
+		return true
+	}
+	return slices.ContainsFunc(t.DLC, func(d DLC) bool { return d.AppID == appID })
+}
+
+// IsExpired reports whether the ownership ticket has expired at now.
+func (t *Ticket) IsExpired(now time.Time) bool {
+	return !t.ExpireTime.IsZero() && now.After(t.ExpireTime)
+}
+
+// Decrypt decrypts an encrypted app ticket with key, verifies its checksum and
+// salted SHA-1 signature, and returns the parsed contents.
+func Decrypt(ticket, key []byte) (*Ticket, error) {
+	if len(key) != KeyLen {
+		return nil, ErrInvalidKey
+	}
+	outer, err := decodeEncryptedAppTicket(ticket)
+	if err != nil {
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(outer.encryptedTicket) != outer.crcEncryptedTicket {
+		return nil, ErrChecksumMismatch
+	}
+	plain, err := symmetricDecrypt(outer.encryptedTicket, key)
+	if err != nil {
+		return nil, err
+	}
+	return parseDecrypted(plain, int(outer.cbEncryptedUserData))
+}
+
+func parseDecrypted(plain []byte, userDataLen int) (*Ticket, error) {
+	if userDataLen < 0 || len(plain) < userDataLen+4 {
+		return nil, ErrMalformedTicket
+	}
+	ownershipLen := int(binary.LittleEndian.Uint32(plain[userDataLen:]))
+	signedLen := userDataLen + ownershipLen
+	if ownershipLen < ownershipMinLen || signedLen > len(plain) {
+		return nil, ErrMalformedTicket
+	}
+
+	rest := plain[signedLen:]
+	if len(rest) < saltLen+digestLen {
+		return nil, ErrUnsigned
+	}
+	h := sha1.New()
+	h.Write(plain[:signedLen])
+	h.Write(rest[:saltLen])
+	if !bytes.Equal(h.Sum(nil), rest[saltLen:saltLen+digestLen]) {
+		return nil, ErrSignatureInvalid
+	}
+	rest = rest[saltLen+digestLen:]
+
+	t, err := parseOwnership(plain[userDataLen:signedLen])
+	if err != nil {
+		return nil, err
+	}
+	t.UserData = slices.Clone(plain[:userDataLen])
+	if len(rest) >= trailerLen && binary.LittleEndian.Uint32(rest)&appDefinedMarker != 0 {
+		t.AppDefinedValue = binary.LittleEndian.Uint32(rest[4:])
+		t.HasAppDefined = true
+	}
+	return t, nil
+}
+
+func parseOwnership(b []byte) (*Ticket, error) {
+	r := reader{buf: b[4:]}
+	t := &Ticket{
+		Version:        r.uint32(),
+		SteamID:        steamworks.CSteamID(r.uint64()),
+		AppID:          steamworks.AppId_t(r.uint32()),
+		ExternalIP:     r.uint32(),
+		InternalIP:     r.uint32(),
+		OwnershipFlags: r.uint32(),
+		IssueTime:      unixTime(r.uint32()),
+		ExpireTime:     unixTime(r.uint32()),
+	}
+	t.Licenses = r.licenses()
+	dlcCount := int(r.uint16())
+	for i := 0; i < dlcCount && r.err == nil; i++ {
+		t.DLC = append(t.DLC, DLC{AppID: steamworks.AppId_t(r.uint32()), Licenses: r.licenses()})
+	}
+	r.uint16() // reserved
+	if r.err != nil {
+		return nil, r.err
+	}
+	return t, nil
+}
+
+func unixTime(sec uint32) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(sec), 0)
+}
+
+// symmetricDecrypt reverses Steam's symmetric encryption: the first block is the
+// AES-ECB encrypted IV, followed by AES-CBC ciphertext with PKCS#7 padding.
+func symmetricDecrypt(data, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	if len(data) < 2*aes.BlockSize || len(data)%aes.BlockSize != 0 {
+		return nil, ErrDecryptFailed
+	}
+	iv := make([]byte, aes.BlockSize)
+	block.Decrypt(iv, data[:aes.BlockSize])
+	plain := make([]byte, len(data)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data[aes.BlockSize:])
+
+	pad := int(plain[len(plain)-1])
+	if pad == 0 || pad > aes.BlockSize || pad > len(plain) {
+		return nil, ErrDecryptFailed
+	}
+	for _, b := range plain[len(plain)-pad:] {
+		if int(b) != pad {
+			return nil, ErrDecryptFailed
+		}
+	}
+	return plain[:len(plain)-pad], nil
+}
+
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil || len(r.buf) < n {
+		r.err = ErrMalformedTicket
+		return make([]byte, n)
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *reader) uint16() uint16 { return binary.LittleEndian.Uint16(r.next(2)) }
+func (r *reader) uint32() uint32 { return binary.LittleEndian.Uint32(r.next(4)) }
+func (r *reader) uint64() uint64 { return binary.LittleEndian.Uint64(r.next(8)) }
+
+func (r *reader) licenses() []uint32 {
+	count := int(r.uint16())
+	var out []uint32
+	for i := 0; i < count && r.err == nil; i++ {
+		out = append(out, r.uint32())
+	}
+	return out
+}
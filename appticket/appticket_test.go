@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package appticket
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"testing"
+	"time"
+
+	"github.com/badhex/go-steamworks"
+)
+
+var testKey = bytes.Repeat([]byte{0x5a}, KeyLen)
+
+type testTicket struct {
+	userData   []byte
+	steamID    uint64
+	appID      uint32
+	issued     uint32
+	expires    uint32
+	licenses   []uint32
+	dlc        []uint32
+	appDefined *uint32
+	badDigest  bool
+}
+
+func (tt testTicket) ownership() []byte {
+	var b []byte
+	b = binary.LittleEndian.AppendUint32(b, 0) // length, patched below
+	b = binary.LittleEndian.AppendUint32(b, 4)
+	b = binary.LittleEndian.AppendUint64(b, tt.steamID)
+	b = binary.LittleEndian.AppendUint32(b, tt.appID)
+	b = binary.LittleEndian.AppendUint32(b, 0x7f000001)
+	b = binary.LittleEndian.AppendUint32(b, 0x0a000001)
+	b = binary.LittleEndian.AppendUint32(b, 0)
+	b = binary.LittleEndian.AppendUint32(b, tt.issued)
+	b = binary.LittleEndian.AppendUint32(b, tt.expires)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(tt.licenses)))
+	for _, l := range tt.licenses {
+		b = binary.LittleEndian.AppendUint32(b, l)
+	}
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(tt.dlc)))
+	for _, d := range tt.dlc {
+		b = binary.LittleEndian.AppendUint32(b, d)
+		b = binary.LittleEndian.AppendUint16(b, 1)
+		b = binary.LittleEndian.AppendUint32(b, d+1)
+	}
+	b = binary.LittleEndian.AppendUint16(b, 0)
+	binary.LittleEndian.PutUint32(b, uint32(len(b)))
+	return b
+}
+
+func (tt testTicket) encode(t *testing.T, key []byte) []byte {
+	t.Helper()
+	plain := append(bytes.Clone(tt.userData), tt.ownership()...)
+	salt := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	h := sha1.New()
+	h.Write(plain)
+	h.Write(salt)
+	digest := h.Sum(nil)
+	if tt.badDigest {
+		digest[0] ^= 0xff
+	}
+	plain = append(plain, salt...)
+	plain = append(plain, digest...)
+	if tt.appDefined != nil {
+		plain = binary.LittleEndian.AppendUint32(plain, appDefinedMarker)
+		plain = binary.LittleEndian.AppendUint32(plain, *tt.appDefined)
+	}
+
+	encrypted := symmetricEncrypt(t, plain, key)
+	var msg []byte
+	msg = appendVarintField(msg, 1, 1)
+	msg = appendVarintField(msg, 2, uint64(crc32.ChecksumIEEE(encrypted)))
+	msg = appendVarintField(msg, 3, uint64(len(tt.userData)))
+	msg = appendVarintField(msg, 4, uint64(len(plain)-len(tt.userData)))
+	msg = binary.AppendUvarint(msg, 5<<3|wireBytes)
+	msg = binary.AppendUvarint(msg, uint64(len(encrypted)))
+	return append(msg, encrypted...)
+}
+
+func appendVarintField(b []byte, field, v uint64) []byte {
+	b = binary.AppendUvarint(b, field<<3|wireVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+func symmetricEncrypt(t *testing.T, plain, key []byte) []byte {
+	t.Helper()
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("aes.NewCipher: %v", err)
+	}
+	pad := aes.BlockSize - len(plain)%aes.BlockSize
+	padded := append(bytes.Clone(plain), bytes.Repeat([]byte{byte(pad)}, pad)...)
+	iv := bytes.Repeat([]byte{0x11}, aes.BlockSize)
+	out := make([]byte, aes.BlockSize+len(padded))
+	block.Encrypt(out[:aes.BlockSize], iv)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(out[aes.BlockSize:], padded)
+	return out
+}
+
+func TestDecrypt(t *testing.T) {
+	value := uint32(0xdeadbeef)
+	tt := testTicket{
+		userData:   []byte("loadout=7"),
+		steamID:    76561197960287930,
+		appID:      480,
+		issued:     1700000000,
+		expires:    1700086400,
+		licenses:   []uint32{1000, 1001},
+		dlc:        []uint32{481},
+		appDefined: &value,
+	}
+	ticket, err := Decrypt(tt.encode(t, testKey), testKey)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got, want := ticket.SteamID, steamworks.CSteamID(tt.steamID); got != want {
+		t.Fatalf("SteamID=%d, want %d", got, want)
+	}
+	if !ticket.IsForApp(480) || ticket.IsForApp(481) {
+		t.Fatalf("IsForApp mismatch for app %d", ticket.AppID)
+	}
+	if !ticket.OwnsApp(481) || ticket.OwnsApp(482) {
+		t.Fatalf("OwnsApp mismatch, DLC=%v", ticket.DLC)
+	}
+	if got, want := ticket.IssueTime, time.Unix(1700000000, 0); !got.Equal(want) {
+		t.Fatalf("IssueTime=%v, want %v", got, want)
+	}
+	if !ticket.IsExpired(time.Unix(1700086401, 0)) || ticket.IsExpired(time.Unix(1700000001, 0)) {
+		t.Fatalf("IsExpired mismatch, ExpireTime=%v", ticket.ExpireTime)
+	}
+	if !bytes.Equal(ticket.UserData, tt.userData) {
+		t.Fatalf("UserData=%q, want %q", ticket.UserData, tt.userData)
+	}
+	if len(ticket.Licenses) != 2 || ticket.Licenses[1] != 1001 {
+		t.Fatalf("Licenses=%v", ticket.Licenses)
+	}
+	if !ticket.HasAppDefined || ticket.AppDefinedValue != value {
+		t.Fatalf("AppDefinedValue=%#x (present %v), want %#x", ticket.AppDefinedValue, ticket.HasAppDefined, value)
+	}
+}
+
+func TestDecryptWithoutAppDefinedValue(t *testing.T) {
+	tt := testTicket{steamID: 1, appID: 480}
+	ticket, err := Decrypt(tt.encode(t, testKey), testKey)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if ticket.HasAppDefined {
+		t.Fatalf("HasAppDefined=true, want false")
+	}
+	if len(ticket.UserData) != 0 {
+		t.Fatalf("UserData=%q, want empty", ticket.UserData)
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	valid := testTicket{steamID: 1, appID: 480}.encode(t, testKey)
+
+	corrupted := bytes.Clone(valid)
+	corrupted[len(corrupted)-1] ^= 0xff
+
+	wrongKey := bytes.Repeat([]byte{0x33}, KeyLen)
+
+	tests := []struct {
+		name   string
+		ticket []byte
+		key    []byte
+		want   error
+	}{
+		{name: "short-key", ticket: valid, key: testKey[:16], want: ErrInvalidKey},
+		{name: "empty", ticket: nil, key: testKey, want: ErrMalformedTicket},
+		{name: "truncated", ticket: valid[:len(valid)-4], key: testKey, want: ErrMalformedTicket},
+		{name: "checksum", ticket: corrupted, key: testKey, want: ErrChecksumMismatch},
+		{name: "bad-digest", ticket: testTicket{steamID: 1, appID: 480, badDigest: true}.encode(t, testKey), key: testKey, want: ErrSignatureInvalid},
+	}
+	for _, tt := range tests {
+		if _, err := Decrypt(tt.ticket, tt.key); !errors.Is(err, tt.want) {
+			t.Fatalf("%s: Decrypt error=%v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	if _, err := Decrypt(valid, wrongKey); err == nil {
+		t.Fatalf("wrong-key: Decrypt succeeded, want error")
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package appticket
+
+import "encoding/binary"
+
+// encryptedAppTicket mirrors the EncryptedAppTicket protobuf message.
+type encryptedAppTicket struct {
+	ticketVersion                 uint32
+	crcEncryptedTicket            uint32
+	cbEncryptedUserData           uint32
+	cbEncryptedAppOwnershipTicket uint32
+	encryptedTicket               []byte
+}
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+func decodeEncryptedAppTicket(b []byte) (encryptedAppTicket, error) {
+	var msg encryptedAppTicket
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return msg, ErrMalformedTicket
+		}
+		b = b[n:]
+		field, wire := tag>>3, tag&7
+		switch wire {
+		case wireVarint:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return msg, ErrMalformedTicket
+			}
+			b = b[n:]
+			switch field {
+			case 1:
+				msg.ticketVersion = uint32(v)
+			case 2:
+				msg.crcEncryptedTicket = uint32(v)
+			case 3:
+				msg.cbEncryptedUserData = uint32(v)
+			case 4:
+				msg.cbEncryptedAppOwnershipTicket = uint32(v)
+			}
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || l > uint64(len(b)-n) {
+				return msg, ErrMalformedTicket
+			}
+			b = b[n:]
+			if field == 5 {
+				msg.encryptedTicket = b[:l]
+			}
+			b = b[l:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return msg, ErrMalformedTicket
+			}
+			b = b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return msg, ErrMalformedTicket
+			}
+			b = b[4:]
+		default:
+			return msg, ErrMalformedTicket
+		}
+	}
+	if len(msg.encryptedTicket) == 0 {
+		return msg, ErrMalformedTicket
+	}
+	return msg, nil
+}
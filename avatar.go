@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"image"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoAvatar        = errors.New("steamworks: user has no avatar")
+	ErrAvatarImageRead = errors.New("steamworks: failed to read avatar image")
+)
+
+// AvatarSize selects which avatar image to load.
+type AvatarSize int
+
+const (
+	AvatarSmall  AvatarSize = iota // 32x32
+	AvatarMedium                   // 64x64
+	AvatarLarge                    // 184x184
+)
+
+// DefaultAvatarCacheSize is the cache capacity used when NewAvatarLoader is
+// given a non-positive capacity.
+const DefaultAvatarCacheSize = 256
+
+// avatarPollInterval bounds how long LoadAvatar waits between handle checks
+// when no callback wakes it.
+const avatarPollInterval = 50 * time.Millisecond
+
+// AvatarLoader loads Steam avatars into image.Image values. Decoded images are
+// kept in an LRU cache keyed by image handle and dropped when a
+// PersonaStateChange_t reports an avatar change for their user.
+type AvatarLoader struct {
+	friends ISteamFriends
+	utils   ISteamUtils
+
+	mu      sync.Mutex
+	cache   *lruCache[int32, image.Image]
+	handles map[CSteamID][]int32
+	wake    chan struct{}
+
+	removes []func()
+}
+
+// NewAvatarLoader constructs a loader caching up to capacity images and
+// subscribes to AvatarImageLoaded_t and PersonaStateChange_t on d. d may be
+// nil, in which case LoadAvatar polls for avatars that are still loading.
+func NewAvatarLoader(friends ISteamFriends, utils ISteamUtils, d *CallbackDispatcher, capacity int) *AvatarLoader {
+	if capacity <= 0 {
+		capacity = DefaultAvatarCacheSize
+	}
+	l := &AvatarLoader{
+		friends: friends,
+		utils:   utils,
+		cache:   newLRUCache[int32, image.Image](capacity),
+		handles: make(map[CSteamID][]int32),
+		wake:    make(chan struct{}),
+	}
+	if d != nil {
+		l.removes = append(l.removes,
+			AddCallback(d, CallbackIDAvatarImageLoaded, func(AvatarImageLoaded) { l.notify() }),
+			AddCallback(d, CallbackIDPersonaStateChange, l.onPersonaStateChange),
+		)
+	}
+	return l
+}
+
+// Close unsubscribes the loader from its dispatcher.
+func (l *AvatarLoader) Close() {
+	for _, remove := range l.removes {
+		remove()
+	}
+	l.removes = nil
+}
+
+// LoadAvatar returns the avatar of steamID at the requested size. It requests
+// user information when Steam does not know the user yet and waits for the
+// image to arrive; callbacks must keep being pumped while it waits.
+// It returns ErrNoAvatar when the user has no avatar.
+func (l *AvatarLoader) LoadAvatar(ctx context.Context, steamID CSteamID, size AvatarSize) (image.Image, error) {
+	requested := false
+	ticker := time.NewTicker(avatarPollInterval)
+	defer ticker.Stop()
+
+	for {
+		l.mu.Lock()
+		wake := l.wake
+		l.mu.Unlock()
+
+		switch handle := l.handle(steamID, size); {
+		case handle > 0:
+			return l.image(steamID, handle)
+		case handle == 0:
+			if requested {
+				break
+			}
+			requested = true
+			// RequestUserInformation returns false when the data is already
+			// available, which means the user has no avatar.
+			if !l.friends.RequestUserInformation(steamID, false) {
+				if handle = l.handle(steamID, size); handle > 0 {
+					return l.image(steamID, handle)
+				}
+				return nil, ErrNoAvatar
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// CachedImages returns the number of decoded images in the cache.
+func (l *AvatarLoader) CachedImages() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.cache.len()
+}
+
+// Invalidate drops cached avatars of steamID.
+func (l *AvatarLoader) Invalidate(steamID CSteamID) {
+	l.mu.Lock()
+	for _, handle := range l.handles[steamID] {
+		l.cache.remove(handle)
+	}
+	delete(l.handles, steamID)
+	l.mu.Unlock()
+}
+
+func (l *AvatarLoader) handle(steamID CSteamID, size AvatarSize) int32 {
+	switch size {
+	case AvatarSmall:
+		return l.friends.GetSmallFriendAvatar(steamID)
+	case AvatarMedium:
+		return l.friends.GetMediumFriendAvatar(steamID)
+	default:
+		return l.friends.GetLargeFriendAvatar(steamID)
+	}
+}
+
+func (l *AvatarLoader) image(steamID CSteamID, handle int32) (image.Image, error) {
+	l.mu.Lock()
+	img, ok := l.cache.get(handle)
+	l.mu.Unlock()
+	if ok {
+		return img, nil
+	}
+
+	width, height, ok := l.utils.GetImageSize(int(handle))
+	if !ok || width == 0 || height == 0 {
+		return nil, ErrAvatarImageRead
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	if !l.utils.GetImageRGBA(int(handle), rgba.Pix) {
+		return nil, ErrAvatarImageRead
+	}
+
+	l.mu.Lock()
+	l.cache.put(handle, rgba)
+	if !slices.Contains(l.handles[steamID], handle) {
+		l.handles[steamID] = append(l.handles[steamID], handle)
+	}
+	l.mu.Unlock()
+	return rgba, nil
+}
+
+func (l *AvatarLoader) onPersonaStateChange(cb PersonaStateChange) {
+	if cb.ChangeFlags.Has(EPersonaChangeAvatar) {
+		l.Invalidate(cb.SteamID)
+	}
+	l.notify()
+}
+
+func (l *AvatarLoader) notify() {
+	l.mu.Lock()
+	close(l.wake)
+	l.wake = make(chan struct{})
+	l.mu.Unlock()
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"image"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeAvatarFriends struct {
+	ISteamFriends
+	mu        sync.Mutex
+	avatars   map[CSteamID]int32
+	unknown   map[CSteamID]bool
+	requested []CSteamID
+}
+
+func (f *fakeAvatarFriends) avatar(id CSteamID) int32 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.avatars[id]
+}
+
+func (f *fakeAvatarFriends) set(id CSteamID, handle int32) {
+	f.mu.Lock()
+	f.avatars[id] = handle
+	delete(f.unknown, id)
+	f.mu.Unlock()
+}
+
+func (f *fakeAvatarFriends) GetSmallFriendAvatar(id CSteamID) int32  { return f.avatar(id) }
+func (f *fakeAvatarFriends) GetMediumFriendAvatar(id CSteamID) int32 { return f.avatar(id) }
+func (f *fakeAvatarFriends) GetLargeFriendAvatar(id CSteamID) int32  { return f.avatar(id) }
+
+func (f *fakeAvatarFriends) RequestUserInformation(id CSteamID, requireNameOnly bool) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requested = append(f.requested, id)
+	return f.unknown[id]
+}
+
+type fakeImageUtils struct {
+	ISteamUtils
+	mu    sync.Mutex
+	reads int
+}
+
+func (f *fakeImageUtils) GetImageSize(image int) (uint32, uint32, bool) {
+	return 2, 1, image > 0
+}
+
+func (f *fakeImageUtils) GetImageRGBA(image int, dest []byte) bool {
+	f.mu.Lock()
+	f.reads++
+	f.mu.Unlock()
+	for i := range dest {
+		dest[i] = byte(image)
+	}
+	return len(dest) == 8
+}
+
+func TestAvatarLoaderCachesByHandle(t *testing.T) {
+	friends := &fakeAvatarFriends{avatars: map[CSteamID]int32{1: 7, 2: 7}}
+	utils := &fakeImageUtils{}
+	d := NewCallbackDispatcher()
+	l := NewAvatarLoader(friends, utils, d, 0)
+	defer l.Close()
+
+	img, err := l.LoadAvatar(context.Background(), 1, AvatarMedium)
+	if err != nil {
+		t.Fatalf("LoadAvatar error=%v", err)
+	}
+	if got, want := img.Bounds(), image.Rect(0, 0, 2, 1); got != want {
+		t.Fatalf("bounds=%v, want %v", got, want)
+	}
+	if r, _, _, _ := img.At(1, 0).RGBA(); r>>8 != 7 {
+		t.Fatalf("pixel red=%d, want 7", r>>8)
+	}
+	if _, err := l.LoadAvatar(context.Background(), 2, AvatarMedium); err != nil {
+		t.Fatalf("LoadAvatar error=%v", err)
+	}
+	if utils.reads != 1 {
+		t.Fatalf("GetImageRGBA calls=%d, want 1 (cached by handle)", utils.reads)
+	}
+
+	dispatchCallback(d, CallbackIDPersonaStateChange, PersonaStateChange{SteamID: 1, ChangeFlags: EPersonaChangeName})
+	if l.CachedImages() != 1 {
+		t.Fatalf("name change evicted avatar")
+	}
+	dispatchCallback(d, CallbackIDPersonaStateChange, PersonaStateChange{SteamID: 1, ChangeFlags: EPersonaChangeAvatar | EPersonaChangeName})
+	if l.CachedImages() != 0 {
+		t.Fatalf("CachedImages=%d after avatar change, want 0", l.CachedImages())
+	}
+}
+
+func TestAvatarLoaderWaitsForImage(t *testing.T) {
+	friends := &fakeAvatarFriends{avatars: map[CSteamID]int32{1: -1}}
+	d := NewCallbackDispatcher()
+	l := NewAvatarLoader(friends, &fakeImageUtils{}, d, 4)
+	defer l.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := l.LoadAvatar(context.Background(), 1, AvatarLarge)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	friends.set(1, 9)
+	dispatchCallback(d, CallbackIDAvatarImageLoaded, AvatarImageLoaded{SteamID: 1, Image: 9, Wide: 2, Tall: 1})
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("LoadAvatar error=%v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("LoadAvatar did not return after AvatarImageLoaded")
+	}
+}
+
+func TestAvatarLoaderRequestsUserInformation(t *testing.T) {
+	friends := &fakeAvatarFriends{avatars: map[CSteamID]int32{}, unknown: map[CSteamID]bool{1: true}}
+	l := NewAvatarLoader(friends, &fakeImageUtils{}, nil, 4)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := l.LoadAvatar(context.Background(), 1, AvatarSmall)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	friends.set(1, 3) // picked up by polling without a dispatcher
+	if err := <-done; err != nil {
+		t.Fatalf("LoadAvatar error=%v", err)
+	}
+	if len(friends.requested) != 1 {
+		t.Fatalf("RequestUserInformation calls=%d, want 1", len(friends.requested))
+	}
+
+	if _, err := l.LoadAvatar(context.Background(), 2, AvatarSmall); !errors.Is(err, ErrNoAvatar) {
+		t.Fatalf("error=%v, want %v", err, ErrNoAvatar)
+	}
+
+	friends.set(3, -1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.LoadAvatar(ctx, 3, AvatarLarge); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error=%v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestLRUCacheEviction(t *testing.T) {
+	c := newLRUCache[int, string](2)
+	c.put(1, "a")
+	c.put(2, "b")
+	c.get(1)
+	c.put(3, "c")
+	if _, ok := c.get(2); ok {
+		t.Fatalf("least recently used entry 2 not evicted")
+	}
+	if v, ok := c.get(1); !ok || v != "a" {
+		t.Fatalf("get(1)=%q, %v, want a, true", v, ok)
+	}
+	c.remove(1)
+	if c.len() != 1 {
+		t.Fatalf("len=%d, want 1", c.len())
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"time"
+)
+
+var (
+	ErrBetaNotFound       = errors.New("steamworks: beta branch not found")
+	ErrBetaNotAvailable   = errors.New("steamworks: beta branch not available")
+	ErrBetaSwitchRejected = errors.New("steamworks: SetActiveBeta rejected")
+)
+
+// Beta describes a beta branch of the running app.
+type Beta struct {
+	Name        string
+	Description string
+	BuildID     uint32
+	LastUpdated time.Time
+	Flags       EBetaBranchFlags
+}
+
+// IsDefault reports whether the branch is the app's default branch.
+func (b Beta) IsDefault() bool { return b.Flags.Has(EBetaBranchDefault) }
+
+// IsAvailable reports whether the user may select the branch.
+func (b Beta) IsAvailable() bool { return b.Flags.Has(EBetaBranchAvailable) }
+
+// IsPrivate reports whether the branch is password protected.
+func (b Beta) IsPrivate() bool { return b.Flags.Has(EBetaBranchPrivate) }
+
+// IsSelected reports whether the branch is selected for the user.
+func (b Beta) IsSelected() bool { return b.Flags.Has(EBetaBranchSelected) }
+
+// IsInstalled reports whether the branch's build is installed.
+func (b Beta) IsInstalled() bool { return b.Flags.Has(EBetaBranchInstalled) }
+
+func betas(apps ISteamApps) iter.Seq[Beta] {
+	return func(yield func(Beta) bool) {
+		total, _, _ := apps.GetNumBetas()
+		for i := 0; i < total; i++ {
+			flags, buildID, lastUpdated, name, description, ok := apps.GetBetaInfo(i)
+			if !ok {
+				continue
+			}
+			b := Beta{
+				Name:        name,
+				Description: description,
+				BuildID:     buildID,
+				Flags:       EBetaBranchFlags(flags),
+			}
+			if lastUpdated != 0 {
+				b.LastUpdated = time.Unix(int64(lastUpdated), 0)
+			}
+			if !yield(b) {
+				return
+			}
+		}
+	}
+}
+
+// FindBeta returns the beta branch with the given name.
+func FindBeta(apps ISteamApps, name string) (Beta, bool) {
+	for b := range apps.Betas() {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return Beta{}, false
+}
+
+// SwitchBeta selects the named beta branch and blocks until Steam reports it
+// as both selected and installed, or until ctx is done. Steam downloads the
+// branch's build in the background, so keep pumping callbacks while waiting.
+// A pollInterval <= 0 defaults to 500ms.
+func SwitchBeta(ctx context.Context, apps ISteamApps, name string, pollInterval time.Duration) (Beta, error) {
+	b, ok := FindBeta(apps, name)
+	if !ok {
+		return Beta{}, ErrBetaNotFound
+	}
+	if !b.IsAvailable() && !b.IsDefault() {
+		return b, ErrBetaNotAvailable
+	}
+	if !apps.SetActiveBeta(name) {
+		return b, ErrBetaSwitchRejected
+	}
+	if pollInterval <= 0 {
+		pollInterval = 500 * time.Millisecond
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		b, ok = FindBeta(apps, name)
+		if !ok {
+			return Beta{}, ErrBetaNotFound
+		}
+		if b.Flags.Has(EBetaBranchSelected | EBetaBranchInstalled) {
+			return b, nil
+		}
+		select {
+		case <-ctx.Done():
+			return b, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
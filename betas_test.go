@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeBetaApps struct {
+	ISteamApps
+	mu       sync.Mutex
+	betas    []Beta
+	switched []string
+	// polls counts GetNumBetas calls; the switch settles after settleAfter polls.
+	polls, settleAfter int
+	reject             bool
+}
+
+func (f *fakeBetaApps) GetNumBetas() (int, int, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.polls++
+	if len(f.switched) > 0 && f.polls >= f.settleAfter {
+		for i := range f.betas {
+			if f.betas[i].Name == f.switched[len(f.switched)-1] {
+				f.betas[i].Flags |= EBetaBranchInstalled
+			} else {
+				f.betas[i].Flags &^= EBetaBranchSelected | EBetaBranchInstalled
+			}
+		}
+	}
+	return len(f.betas), 0, 0
+}
+
+func (f *fakeBetaApps) GetBetaInfo(i int) (uint32, uint32, uint32, string, string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if i >= len(f.betas) {
+		return 0, 0, 0, "", "", false
+	}
+	b := f.betas[i]
+	return uint32(b.Flags), b.BuildID, uint32(b.LastUpdated.Unix()), b.Name, b.Description, true
+}
+
+func (f *fakeBetaApps) SetActiveBeta(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.reject {
+		return false
+	}
+	f.switched = append(f.switched, name)
+	for i := range f.betas {
+		if f.betas[i].Name == name {
+			f.betas[i].Flags |= EBetaBranchSelected
+		}
+	}
+	return true
+}
+
+func (f *fakeBetaApps) Betas() iter.Seq[Beta] { return betas(f) }
+
+func newFakeBetaApps() *fakeBetaApps {
+	return &fakeBetaApps{betas: []Beta{
+		{Name: "public", BuildID: 100, LastUpdated: time.Unix(1700000000, 0), Flags: EBetaBranchDefault | EBetaBranchAvailable | EBetaBranchSelected | EBetaBranchInstalled},
+		{Name: "staging", Description: "Test branch", BuildID: 101, LastUpdated: time.Unix(1700000100, 0), Flags: EBetaBranchAvailable},
+		{Name: "internal", BuildID: 102, LastUpdated: time.Unix(1700000200, 0), Flags: EBetaBranchPrivate},
+	}}
+}
+
+func TestBetasIterator(t *testing.T) {
+	apps := newFakeBetaApps()
+	got := slices.Collect(betas(apps))
+	if !slices.EqualFunc(got, apps.betas, func(a, b Beta) bool {
+		return a.Name == b.Name && a.Description == b.Description && a.BuildID == b.BuildID && a.Flags == b.Flags && a.LastUpdated.Equal(b.LastUpdated)
+	}) {
+		t.Fatalf("betas()=%+v, want %+v", got, apps.betas)
+	}
+	if !got[0].IsDefault() || !got[0].IsInstalled() || got[1].IsSelected() || !got[2].IsPrivate() || got[2].IsAvailable() {
+		t.Fatalf("decoded flags mismatch: %+v", got)
+	}
+
+	var s steamApps
+	_ = s.Betas()
+}
+
+func TestSwitchBeta(t *testing.T) {
+	apps := newFakeBetaApps()
+	apps.settleAfter = 4
+
+	b, err := SwitchBeta(context.Background(), apps, "staging", time.Millisecond)
+	if err != nil {
+		t.Fatalf("SwitchBeta error=%v", err)
+	}
+	if !b.IsSelected() || !b.IsInstalled() || b.Name != "staging" {
+		t.Fatalf("SwitchBeta=%+v, want staging selected and installed", b)
+	}
+	if public, _ := FindBeta(apps, "public"); public.IsSelected() {
+		t.Fatalf("public still selected after switch")
+	}
+}
+
+func TestSwitchBetaErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch string
+		reject bool
+		want   error
+	}{
+		{name: "unknown", branch: "missing", want: ErrBetaNotFound},
+		{name: "private", branch: "internal", want: ErrBetaNotAvailable},
+		{name: "rejected", branch: "staging", reject: true, want: ErrBetaSwitchRejected},
+	}
+	for _, tt := range tests {
+		apps := newFakeBetaApps()
+		apps.reject = tt.reject
+		if _, err := SwitchBeta(context.Background(), apps, tt.branch, time.Millisecond); !errors.Is(err, tt.want) {
+			t.Fatalf("%s: error=%v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	apps := newFakeBetaApps()
+	apps.settleAfter = 1 << 30
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := SwitchBeta(ctx, apps, "staging", time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error=%v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -4,6 +4,7 @@
 package steamworks
 
 import (
+	"slices"
 	"sync"
 	"unsafe"
 )
@@ -13,7 +14,20 @@ type CallbackID int32
 
 type callbackHandler struct {
 	size uintptr
-	fn   func(unsafe.Pointer)
+	// registered is the handler set by RegisterCallback; fns are those added
+	// by AddCallback.
+	registered *callbackFunc
+	fns        []*callbackFunc
+}
+
+type callbackFunc struct {
+	fn func(unsafe.Pointer)
+}
+
+func typedCallback[T any](handler func(T)) *callbackFunc {
+	return &callbackFunc{fn: func(ptr unsafe.Pointer) {
+		handler(*(*T)(ptr))
+	}}
 }
 
 // CallbackDispatcher stores typed callback handlers keyed by callback ID.
@@ -30,20 +44,55 @@ func NewCallbackDispatcher() *CallbackDispatcher {
 	}
 }
 
-// RegisterCallback registers a typed handler for a callback ID.
+// RegisterCallback registers a typed handler for a callback ID, replacing the
+// handler previously registered for it. Handlers added with AddCallback are
+// kept and run after it.
 func RegisterCallback[T any](d *CallbackDispatcher, id CallbackID, handler func(T)) {
 	var zero T
 	d.mu.Lock()
-	d.handlers[id] = callbackHandler{
-		size: unsafe.Sizeof(zero),
-		fn: func(ptr unsafe.Pointer) {
-			handler(*(*T)(ptr))
-		},
-	}
+	h := d.handlers[id]
+	h.size = unsafe.Sizeof(zero)
+	h.registered = typedCallback(handler)
+	d.handlers[id] = h
+	d.mu.Unlock()
+}
+
+// AddCallback adds a typed handler for a callback ID alongside any existing
+// handlers. Calling the returned function removes it again.
+func AddCallback[T any](d *CallbackDispatcher, id CallbackID, handler func(T)) (remove func()) {
+	var zero T
+	f := typedCallback(handler)
+	d.mu.Lock()
+	h := d.handlers[id]
+	h.size = unsafe.Sizeof(zero)
+	h.fns = append(slices.Clip(h.fns), f)
+	d.handlers[id] = h
 	d.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { d.removeCallback(id, f) })
+	}
+}
+
+func (d *CallbackDispatcher) removeCallback(id CallbackID, f *callbackFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	h, ok := d.handlers[id]
+	if !ok {
+		return
+	}
+	// Build a new slice so in-flight dispatches keep a stable view.
+	fns := slices.DeleteFunc(slices.Clone(h.fns), func(c *callbackFunc) bool { return c == f })
+	if len(fns) == 0 && h.registered == nil {
+		delete(d.handlers, id)
+		return
+	}
+	h.fns = fns
+	d.handlers[id] = h
 }
 
-// Dispatch invokes the registered handler for the callback ID, if any.
+// Dispatch invokes the handlers registered for the callback ID, if any.
 func (d *CallbackDispatcher) Dispatch(id CallbackID, data unsafe.Pointer) bool {
 	d.mu.RLock()
 	handler, ok := d.handlers[id]
@@ -51,7 +100,12 @@ func (d *CallbackDispatcher) Dispatch(id CallbackID, data unsafe.Pointer) bool {
 	if !ok {
 		return false
 	}
-	handler.fn(data)
+	if handler.registered != nil {
+		handler.registered.fn(data)
+	}
+	for _, f := range handler.fns {
+		f.fn(data)
+	}
 	return true
 }
 
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"slices"
+	"testing"
+	"unsafe"
+)
+
+func TestCallbackDispatcherAddCallback(t *testing.T) {
+	d := NewCallbackDispatcher()
+	var got []string
+	removeA := AddCallback(d, CallbackIDDlcInstalled, func(cb DlcInstalled) { got = append(got, "a") })
+	AddCallback(d, CallbackIDDlcInstalled, func(cb DlcInstalled) { got = append(got, "b") })
+
+	payload := DlcInstalled{AppID: 1}
+	if !d.Dispatch(CallbackIDDlcInstalled, unsafe.Pointer(&payload)) {
+		t.Fatalf("Dispatch=false, want true")
+	}
+	removeA()
+	removeA()
+	d.Dispatch(CallbackIDDlcInstalled, unsafe.Pointer(&payload))
+	if want := []string{"a", "b", "b"}; !slices.Equal(got, want) {
+		t.Fatalf("handlers ran %v, want %v", got, want)
+	}
+	if size, ok := d.ExpectedSize(CallbackIDDlcInstalled); !ok || size != 4 {
+		t.Fatalf("ExpectedSize=%d, %v, want 4, true", size, ok)
+	}
+
+}
+
+func TestCallbackDispatcherRegisterCallbackKeepsAdded(t *testing.T) {
+	d := NewCallbackDispatcher()
+	var got []string
+	remove := AddCallback(d, CallbackIDDlcInstalled, func(cb DlcInstalled) { got = append(got, "added") })
+	RegisterCallback(d, CallbackIDDlcInstalled, func(cb DlcInstalled) { got = append(got, "first") })
+	RegisterCallback(d, CallbackIDDlcInstalled, func(cb DlcInstalled) { got = append(got, "second") })
+
+	payload := DlcInstalled{AppID: 1}
+	d.Dispatch(CallbackIDDlcInstalled, unsafe.Pointer(&payload))
+	if want := []string{"second", "added"}; !slices.Equal(got, want) {
+		t.Fatalf("handlers ran %v, want %v", got, want)
+	}
+
+	// Removing the last added handler keeps the registered one.
+	remove()
+	got = nil
+	if !d.Dispatch(CallbackIDDlcInstalled, unsafe.Pointer(&payload)) {
+		t.Fatalf("Dispatch=false after remove, want true")
+	}
+	if want := []string{"second"}; !slices.Equal(got, want) {
+		t.Fatalf("handlers ran %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// clanChatMessageMax is the buffer size the SDK recommends for GetClanChatMessage.
+const clanChatMessageMax = 2048
+
+var (
+	ErrClanOfficerListFailed   = errors.New("steamworks: clan officer list request failed")
+	ErrClanActivityCountFailed = errors.New("steamworks: clan activity count download failed")
+	ErrClanChatJoinFailed      = errors.New("steamworks: joining clan chat room failed")
+	ErrClanChatSendFailed      = errors.New("steamworks: sending clan chat message failed")
+)
+
+// Clan is a snapshot of a Steam group the local user belongs to.
+type Clan struct {
+	ID                CSteamID
+	Name              string
+	Tag               string
+	Owner             CSteamID
+	Public            bool
+	OfficialGameGroup bool
+	// Online, InGame and Chatting are the activity counts last downloaded with
+	// DownloadClanActivityCounts; they are zero until then.
+	Online   int
+	InGame   int
+	Chatting int
+}
+
+// LookupClan reads the cached state of clanID from Steam.
+func LookupClan(friends ISteamFriends, clanID CSteamID) Clan {
+	c := Clan{
+		ID:                clanID,
+		Name:              friends.GetClanName(clanID),
+		Tag:               friends.GetClanTag(clanID),
+		Owner:             friends.GetClanOwner(clanID),
+		Public:            friends.IsClanPublic(clanID),
+		OfficialGameGroup: friends.IsClanOfficialGameGroup(clanID),
+	}
+	c.Online, c.InGame, c.Chatting, _ = friends.GetClanActivityCounts(clanID)
+	return c
+}
+
+// RequestClanOfficers downloads the officer list of clanID and returns it.
+// A pollInterval <= 0 uses the CallResult.Wait default.
+func RequestClanOfficers(ctx context.Context, friends ISteamFriends, clanID CSteamID, pollInterval time.Duration) ([]CSteamID, error) {
+	call := friends.RequestClanOfficerList(clanID)
+	if call == 0 {
+		return nil, ErrClanOfficerListFailed
+	}
+	result, failed, err := NewCallResult[ClanOfficerListResponse](call, int32(CallbackIDClanOfficerListResponse)).Wait(ctx, pollInterval)
+	if err != nil {
+		return nil, err
+	}
+	if failed || result.Success == 0 {
+		return nil, ErrClanOfficerListFailed
+	}
+	return slices.Collect(friends.ClanOfficers(clanID)), nil
+}
+
+// RefreshClanActivityCounts downloads activity counts for clanIDs so that
+// GetClanActivityCounts and LookupClan report them.
+// A pollInterval <= 0 uses the CallResult.Wait default.
+func RefreshClanActivityCounts(ctx context.Context, friends ISteamFriends, clanIDs []CSteamID, pollInterval time.Duration) error {
+	call := friends.DownloadClanActivityCounts(clanIDs)
+	if call == 0 {
+		return ErrClanActivityCountFailed
+	}
+	result, failed, err := NewCallResult[DownloadClanActivityCountsResult](call, int32(CallbackIDDownloadClanActivityCountsResult)).Wait(ctx, pollInterval)
+	if err != nil {
+		return err
+	}
+	if failed || !result.Success {
+		return ErrClanActivityCountFailed
+	}
+	return nil
+}
+
+// ClanChatEventType identifies the kind of ClanChatEvent.
+type ClanChatEventType int
+
+const (
+	ClanChatMessage ClanChatEventType = iota
+	ClanChatMemberJoined
+	ClanChatMemberLeft
+)
+
+// ClanChatEvent reports a message or membership change in a clan chat room.
+type ClanChatEvent struct {
+	Type ClanChatEventType
+	User CSteamID
+	// Text and EntryType are set for ClanChatMessage events.
+	Text      string
+	EntryType EChatEntryType
+	// Kicked and Dropped are set for ClanChatMemberLeft events.
+	Kicked  bool
+	Dropped bool
+}
+
+// ClanChatRoom is a joined clan chat room. It turns GameConnectedClanChatMsg_t,
+// GameConnectedChatJoin_t and GameConnectedChatLeave_t for the room into
+// ClanChatEvent values.
+type ClanChatRoom struct {
+	friends ISteamFriends
+	id      CSteamID
+
+	events  eventSource[ClanChatEvent]
+	removes []func()
+}
+
+// JoinClanChat joins the chat room of clanID and waits for Steam to confirm
+// it. A pollInterval <= 0 uses the CallResult.Wait default.
+func JoinClanChat(ctx context.Context, friends ISteamFriends, d *CallbackDispatcher, clanID CSteamID, pollInterval time.Duration) (*ClanChatRoom, error) {
+	call := friends.JoinClanChatRoom(clanID)
+	if call == 0 {
+		return nil, ErrClanChatJoinFailed
+	}
+	payload, failed, err := NewCallResult[joinClanChatPayload](call, int32(CallbackIDJoinClanChatRoomCompletionResult)).Wait(ctx, pollInterval)
+	if err != nil {
+		return nil, err
+	}
+	if failed {
+		return nil, ErrClanChatJoinFailed
+	}
+	result := payload.result()
+	if result.ChatRoomEnterResponse != EChatRoomEnterResponseSuccess {
+		return nil, fmt.Errorf("%w: enter response %d", ErrClanChatJoinFailed, result.ChatRoomEnterResponse)
+	}
+	return NewClanChatRoom(friends, d, result.SteamIDClanChat), nil
+}
+
+// NewClanChatRoom wraps an already joined clan chat room and subscribes to its
+// chat callbacks on d. d may be nil.
+func NewClanChatRoom(friends ISteamFriends, d *CallbackDispatcher, clanChatID CSteamID) *ClanChatRoom {
+	r := &ClanChatRoom{friends: friends, id: clanChatID}
+	if d != nil {
+		r.removes = append(r.removes,
+			AddCallback(d, CallbackIDGameConnectedClanChatMsg, r.onMessage),
+			AddCallback(d, CallbackIDGameConnectedChatJoin, r.onJoin),
+			AddCallback(d, CallbackIDGameConnectedChatLeave, r.onLeave),
+		)
+	}
+	return r
+}
+
+// ID returns the clan chat room's Steam ID.
+func (r *ClanChatRoom) ID() CSteamID {
+	return r.id
+}
+
+// Members returns the users currently in the room.
+func (r *ClanChatRoom) Members() []CSteamID {
+	return slices.Collect(r.friends.ClanChatMembers(r.id))
+}
+
+// IsAdmin reports whether user is an admin of the room.
+func (r *ClanChatRoom) IsAdmin(user CSteamID) bool {
+	return r.friends.IsClanChatAdmin(r.id, user)
+}
+
+// Send posts a message to the room.
+func (r *ClanChatRoom) Send(text string) error {
+	if !r.friends.SendClanChatMessage(r.id, text) {
+		return ErrClanChatSendFailed
+	}
+	return nil
+}
+
+// Subscribe registers fn for room events. Calling the returned function
+// unsubscribes it.
+func (r *ClanChatRoom) Subscribe(fn func(ClanChatEvent)) (unsubscribe func()) {
+	return r.events.subscribe(fn)
+}
+
+// Close unsubscribes the room from its dispatcher without leaving it.
+func (r *ClanChatRoom) Close() {
+	for _, remove := range r.removes {
+		remove()
+	}
+	r.removes = nil
+}
+
+// Leave leaves the room and closes it.
+func (r *ClanChatRoom) Leave() bool {
+	r.Close()
+	return r.friends.LeaveClanChatRoom(r.id)
+}
+
+func (r *ClanChatRoom) onMessage(cb GameConnectedClanChatMsg) {
+	if cb.SteamIDClanChat != r.id {
+		return
+	}
+	buf := make([]byte, clanChatMessageMax)
+	n, entryType, chatter := r.friends.GetClanChatMessage(r.id, int(cb.MessageID), buf)
+	if n <= 0 {
+		return
+	}
+	if chatter == 0 {
+		chatter = cb.SteamIDUser
+	}
+	r.events.emit(ClanChatEvent{
+		Type:      ClanChatMessage,
+		User:      chatter,
+		Text:      cStringToGo(buf[:min(n, len(buf))]),
+		EntryType: entryType,
+	})
+}
+
+func (r *ClanChatRoom) onJoin(cb GameConnectedChatJoin) {
+	if cb.SteamIDClanChat == r.id {
+		r.events.emit(ClanChatEvent{Type: ClanChatMemberJoined, User: cb.SteamIDUser})
+	}
+}
+
+func (r *ClanChatRoom) onLeave(cb GameConnectedChatLeave) {
+	if cb.SteamIDClanChat == r.id {
+		r.events.emit(ClanChatEvent{Type: ClanChatMemberLeft, User: cb.SteamIDUser, Kicked: cb.Kicked, Dropped: cb.Dropped})
+	}
+}
+
+// joinClanChatPayload mirrors JoinClanChatRoomCompletionResult_t. The SDK
+// declares CSteamID with 1-byte packing, so the struct is 12 bytes on every
+// platform and the chat room ID is read as two halves.
+type joinClanChatPayload struct {
+	SteamIDClanChatLo     uint32
+	SteamIDClanChatHi     uint32
+	ChatRoomEnterResponse EChatRoomEnterResponse
+}
+
+func (p joinClanChatPayload) result() JoinClanChatRoomCompletionResult {
+	return JoinClanChatRoomCompletionResult{
+		SteamIDClanChat:       CSteamID(uint64(p.SteamIDClanChatHi)<<32 | uint64(p.SteamIDClanChatLo)),
+		ChatRoomEnterResponse: p.ChatRoomEnterResponse,
+	}
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+)
+
+type fakeClanFriends struct {
+	ISteamFriends
+	messages map[int]string
+	sent     []string
+	left     bool
+}
+
+func (f *fakeClanFriends) GetClanChatMessage(_ CSteamID, messageID int, text []byte) (int, EChatEntryType, CSteamID) {
+	msg, ok := f.messages[messageID]
+	if !ok {
+		return 0, EChatEntryTypeInvalid, 0
+	}
+	n := copy(text, msg+"\x00")
+	return n, EChatEntryTypeChatMsg, CSteamID(messageID)
+}
+
+func (f *fakeClanFriends) SendClanChatMessage(_ CSteamID, text string) bool {
+	f.sent = append(f.sent, text)
+	return text != ""
+}
+
+func (f *fakeClanFriends) LeaveClanChatRoom(CSteamID) bool {
+	f.left = true
+	return true
+}
+
+func TestClanChatRoomEvents(t *testing.T) {
+	const room CSteamID = 0x170000000000001
+	friends := &fakeClanFriends{messages: map[int]string{7: "hello"}}
+	d := NewCallbackDispatcher()
+	r := NewClanChatRoom(friends, d, room)
+
+	var events []ClanChatEvent
+	r.Subscribe(func(e ClanChatEvent) { events = append(events, e) })
+
+	dispatchCallback(d, CallbackIDGameConnectedChatJoin, GameConnectedChatJoin{SteamIDClanChat: room, SteamIDUser: 5})
+	dispatchCallback(d, CallbackIDGameConnectedClanChatMsg, GameConnectedClanChatMsg{SteamIDClanChat: room, SteamIDUser: 7, MessageID: 7})
+	dispatchCallback(d, CallbackIDGameConnectedClanChatMsg, GameConnectedClanChatMsg{SteamIDClanChat: room + 1, SteamIDUser: 7, MessageID: 7})
+	dispatchCallback(d, CallbackIDGameConnectedChatLeave, GameConnectedChatLeave{SteamIDClanChat: room, SteamIDUser: 5, Kicked: true})
+
+	want := []ClanChatEvent{
+		{Type: ClanChatMemberJoined, User: 5},
+		{Type: ClanChatMessage, User: 7, Text: "hello", EntryType: EChatEntryTypeChatMsg},
+		{Type: ClanChatMemberLeft, User: 5, Kicked: true},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events=%+v, want %+v", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Fatalf("event %d=%+v, want %+v", i, events[i], want[i])
+		}
+	}
+
+	if err := r.Send("hi"); err != nil {
+		t.Fatalf("Send error=%v", err)
+	}
+	if err := r.Send(""); !errors.Is(err, ErrClanChatSendFailed) {
+		t.Fatalf("Send error=%v, want %v", err, ErrClanChatSendFailed)
+	}
+
+	if !r.Leave() || !friends.left {
+		t.Fatalf("Leave did not leave the room")
+	}
+	dispatchCallback(d, CallbackIDGameConnectedChatJoin, GameConnectedChatJoin{SteamIDClanChat: room, SteamIDUser: 6})
+	if len(events) != len(want) {
+		t.Fatalf("event delivered after Leave: %+v", events[len(want):])
+	}
+}
+
+func TestClanCallbackLayout(t *testing.T) {
+	var msg GameConnectedClanChatMsg
+	if got, want := unsafe.Offsetof(msg.MessageID), uintptr(16); got != want {
+		t.Fatalf("GameConnectedClanChatMsg.MessageID offset=%d, want %d", got, want)
+	}
+	var leave GameConnectedChatLeave
+	if got, want := unsafe.Offsetof(leave.Dropped), uintptr(17); got != want {
+		t.Fatalf("GameConnectedChatLeave.Dropped offset=%d, want %d", got, want)
+	}
+	var joined JoinClanChatRoomCompletionResult
+	if got, want := unsafe.Offsetof(joined.ChatRoomEnterResponse), uintptr(8); got != want {
+		t.Fatalf("JoinClanChatRoomCompletionResult.ChatRoomEnterResponse offset=%d, want %d", got, want)
+	}
+}
+
+func TestJoinClanChatPayloadLayout(t *testing.T) {
+	var p joinClanChatPayload
+	if got := unsafe.Sizeof(p); got != 12 {
+		t.Fatalf("joinClanChatPayload size=%d, want 12", got)
+	}
+
+	const chat CSteamID = 0x0170000000000001
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(&p)), unsafe.Sizeof(p))
+	putUint64(buf, uint64(chat))
+	if got := p.result().SteamIDClanChat; got != chat {
+		t.Fatalf("SteamIDClanChat=%#x, want %#x", got, chat)
+	}
+}
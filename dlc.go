@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"iter"
+	"slices"
+	"sync"
+)
+
+// DLC describes a DLC app of the running game.
+type DLC struct {
+	AppID     AppId_t
+	Name      string
+	Available bool
+	Installed bool
+}
+
+// DLCProgress is the download state of an in-flight DLC install.
+type DLCProgress struct {
+	AppID      AppId_t
+	Downloaded uint64
+	Total      uint64
+}
+
+// Fraction returns the completed share of the download in [0, 1].
+func (p DLCProgress) Fraction() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Downloaded) / float64(p.Total)
+}
+
+// DLCEventType identifies what changed in a DLCEvent.
+type DLCEventType int
+
+const (
+	// DLCEventInstalled is emitted when a DLC finishes installing.
+	DLCEventInstalled DLCEventType = iota
+	// DLCEventUninstalled is emitted when a DLC is no longer installed.
+	DLCEventUninstalled
+	// DLCEventOwnershipChanged is emitted when a DLC becomes available or unavailable.
+	DLCEventOwnershipChanged
+	// DLCEventProgress is emitted when an in-flight download reports new progress.
+	DLCEventProgress
+)
+
+// DLCEvent reports a change observed by a DLCManager.
+type DLCEvent struct {
+	Type     DLCEventType
+	DLC      DLC
+	Progress DLCProgress
+}
+
+// DLCManager keeps a single view of the game's DLC, their install state and
+// in-flight downloads. It refreshes on DlcInstalled_t,
+// AppProofOfPurchaseKeyResponse_t and NewUrlLaunchParameters_t callbacks
+// delivered through the dispatcher; call Update periodically to poll the
+// progress of downloads started with Install.
+type DLCManager struct {
+	apps ISteamApps
+
+	mu       sync.Mutex
+	dlc      []DLC
+	index    map[AppId_t]int
+	inFlight map[AppId_t]DLCProgress
+
+	events  eventSource[DLCEvent]
+	removes []func()
+}
+
+// NewDLCManager enumerates the DLC reported by apps and subscribes to the DLC
+// callbacks on d. d may be nil when callbacks are not dispatched.
+func NewDLCManager(apps ISteamApps, d *CallbackDispatcher) *DLCManager {
+	m := &DLCManager{
+		apps:     apps,
+		index:    make(map[AppId_t]int),
+		inFlight: make(map[AppId_t]DLCProgress),
+	}
+	m.Refresh()
+	if d != nil {
+		m.removes = append(m.removes,
+			AddCallback(d, CallbackIDDlcInstalled, m.onDlcInstalled),
+			AddCallback(d, CallbackIDAppProofOfPurchaseKeyResponse, func(AppProofOfPurchaseKeyResponse) { m.Refresh() }),
+			AddCallback(d, CallbackIDNewUrlLaunchParameters, func(NewUrlLaunchParameters) { m.Refresh() }),
+		)
+	}
+	return m
+}
+
+// Close unsubscribes the manager from its dispatcher.
+func (m *DLCManager) Close() {
+	m.mu.Lock()
+	removes := m.removes
+	m.removes = nil
+	m.mu.Unlock()
+	for _, remove := range removes {
+		remove()
+	}
+}
+
+// Subscribe registers fn for DLC events. Calling the returned function
+// unsubscribes it.
+func (m *DLCManager) Subscribe(fn func(DLCEvent)) (unsubscribe func()) {
+	return m.events.subscribe(fn)
+}
+
+// All yields the known DLC in Steam's index order.
+func (m *DLCManager) All() iter.Seq[DLC] {
+	m.mu.Lock()
+	dlc := m.dlc
+	m.mu.Unlock()
+	return func(yield func(DLC) bool) {
+		for _, d := range dlc {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// Get returns the DLC with the given app ID.
+func (m *DLCManager) Get(appID AppId_t) (DLC, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i, ok := m.index[appID]
+	if !ok {
+		return DLC{}, false
+	}
+	return m.dlc[i], true
+}
+
+// Refresh re-enumerates the DLC and emits events for changes in ownership or
+// install state since the previous enumeration.
+func (m *DLCManager) Refresh() {
+	count := int(m.apps.GetDLCCount())
+	dlc := make([]DLC, 0, max(count, 0))
+	index := make(map[AppId_t]int, max(count, 0))
+	for i := range count {
+		appID, available, name, ok := m.apps.BGetDLCDataByIndex(i)
+		if !ok {
+			continue
+		}
+		index[appID] = len(dlc)
+		dlc = append(dlc, DLC{
+			AppID:     appID,
+			Name:      name,
+			Available: available,
+			Installed: m.apps.BIsDlcInstalled(appID),
+		})
+	}
+
+	var events []DLCEvent
+	m.mu.Lock()
+	if m.dlc != nil {
+		for _, cur := range dlc {
+			prev, known := m.lookup(cur.AppID)
+			if !known || prev.Available != cur.Available {
+				events = append(events, DLCEvent{Type: DLCEventOwnershipChanged, DLC: cur})
+			}
+			if known && prev.Installed != cur.Installed {
+				events = append(events, m.installEvent(cur))
+			}
+		}
+	}
+	m.dlc, m.index = dlc, index
+	m.mu.Unlock()
+
+	for _, e := range events {
+		m.events.emit(e)
+	}
+}
+
+// Install requests installation of a DLC and tracks its download progress.
+func (m *DLCManager) Install(appID AppId_t) {
+	m.mu.Lock()
+	m.inFlight[appID] = DLCProgress{AppID: appID}
+	m.mu.Unlock()
+	m.apps.InstallDLC(appID)
+}
+
+// Uninstall requests removal of a DLC.
+func (m *DLCManager) Uninstall(appID AppId_t) {
+	m.mu.Lock()
+	delete(m.inFlight, appID)
+	m.mu.Unlock()
+	m.apps.UninstallDLC(appID)
+}
+
+// Progress returns the last polled progress of an in-flight install.
+func (m *DLCManager) Progress(appID AppId_t) (DLCProgress, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.inFlight[appID]
+	return p, ok
+}
+
+// Downloads yields the progress of every in-flight install.
+func (m *DLCManager) Downloads() iter.Seq[DLCProgress] {
+	m.mu.Lock()
+	downloads := make([]DLCProgress, 0, len(m.inFlight))
+	for _, p := range m.inFlight {
+		downloads = append(downloads, p)
+	}
+	m.mu.Unlock()
+	return func(yield func(DLCProgress) bool) {
+		for _, p := range downloads {
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// Update polls GetDlcDownloadProgress for in-flight installs and emits
+// DLCEventProgress when it changes.
+func (m *DLCManager) Update() {
+	m.mu.Lock()
+	pending := make([]AppId_t, 0, len(m.inFlight))
+	for appID := range m.inFlight {
+		pending = append(pending, appID)
+	}
+	m.mu.Unlock()
+
+	var events []DLCEvent
+	for _, appID := range pending {
+		downloaded, total, ok := m.apps.GetDlcDownloadProgress(appID)
+		if !ok {
+			continue
+		}
+		p := DLCProgress{AppID: appID, Downloaded: downloaded, Total: total}
+		m.mu.Lock()
+		prev, stillPending := m.inFlight[appID]
+		if stillPending && prev != p {
+			m.inFlight[appID] = p
+			d, _ := m.lookup(appID)
+			events = append(events, DLCEvent{Type: DLCEventProgress, DLC: d, Progress: p})
+		}
+		m.mu.Unlock()
+	}
+	for _, e := range events {
+		m.events.emit(e)
+	}
+}
+
+func (m *DLCManager) onDlcInstalled(cb DlcInstalled) {
+	m.mu.Lock()
+	delete(m.inFlight, cb.AppID)
+	i, ok := m.index[cb.AppID]
+	var event DLCEvent
+	if ok {
+		// Copy on write: All hands out the slice without holding the lock.
+		m.dlc = slices.Clone(m.dlc)
+		m.dlc[i].Installed = true
+		event = m.installEvent(m.dlc[i])
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		// Unknown DLC, e.g. purchased after the last enumeration.
+		m.Refresh()
+		d, known := m.Get(cb.AppID)
+		if !known {
+			d = DLC{AppID: cb.AppID, Installed: true}
+		}
+		event = DLCEvent{Type: DLCEventInstalled, DLC: d}
+	}
+	m.events.emit(event)
+}
+
+// lookup must be called with m.mu held.
+func (m *DLCManager) lookup(appID AppId_t) (DLC, bool) {
+	i, ok := m.index[appID]
+	if !ok {
+		return DLC{AppID: appID}, false
+	}
+	return m.dlc[i], true
+}
+
+func (m *DLCManager) installEvent(d DLC) DLCEvent {
+	if d.Installed {
+		return DLCEvent{Type: DLCEventInstalled, DLC: d}
+	}
+	return DLCEvent{Type: DLCEventUninstalled, DLC: d}
+}
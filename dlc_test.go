@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"slices"
+	"testing"
+	"unsafe"
+)
+
+type fakeDLC struct {
+	DLC
+	downloaded, total uint64
+}
+
+type fakeApps struct {
+	ISteamApps
+	dlc       []fakeDLC
+	installed []AppId_t
+}
+
+func (f *fakeApps) GetDLCCount() int32 { return int32(len(f.dlc)) }
+
+func (f *fakeApps) BGetDLCDataByIndex(i int) (AppId_t, bool, string, bool) {
+	if i < 0 || i >= len(f.dlc) {
+		return 0, false, "", false
+	}
+	d := f.dlc[i]
+	return d.AppID, d.Available, d.Name, true
+}
+
+func (f *fakeApps) BIsDlcInstalled(appID AppId_t) bool {
+	for _, d := range f.dlc {
+		if d.AppID == appID {
+			return d.Installed
+		}
+	}
+	return false
+}
+
+func (f *fakeApps) GetDlcDownloadProgress(appID AppId_t) (uint64, uint64, bool) {
+	for _, d := range f.dlc {
+		if d.AppID == appID && d.total > 0 {
+			return d.downloaded, d.total, true
+		}
+	}
+	return 0, 0, false
+}
+
+func (f *fakeApps) InstallDLC(appID AppId_t)   { f.installed = append(f.installed, appID) }
+func (f *fakeApps) UninstallDLC(appID AppId_t) {}
+
+func dispatchCallback[T any](d *CallbackDispatcher, id CallbackID, payload T) {
+	d.Dispatch(id, unsafe.Pointer(&payload))
+}
+
+func TestDLCManagerEnumerates(t *testing.T) {
+	apps := &fakeApps{dlc: []fakeDLC{
+		{DLC: DLC{AppID: 10, Name: "Soundtrack", Available: true, Installed: true}},
+		{DLC: DLC{AppID: 11, Name: "Expansion"}},
+	}}
+	m := NewDLCManager(apps, nil)
+
+	got := slices.Collect(m.All())
+	want := []DLC{apps.dlc[0].DLC, apps.dlc[1].DLC}
+	if !slices.Equal(got, want) {
+		t.Fatalf("All()=%+v, want %+v", got, want)
+	}
+	if d, ok := m.Get(11); !ok || d.Name != "Expansion" {
+		t.Fatalf("Get(11)=%+v, %v, want Expansion", d, ok)
+	}
+	if _, ok := m.Get(99); ok {
+		t.Fatalf("Get(99) ok=true, want false")
+	}
+}
+
+func TestDLCManagerInstallProgress(t *testing.T) {
+	apps := &fakeApps{dlc: []fakeDLC{{DLC: DLC{AppID: 10, Name: "Expansion", Available: true}}}}
+	d := NewCallbackDispatcher()
+	m := NewDLCManager(apps, d)
+	defer m.Close()
+
+	var events []DLCEvent
+	m.Subscribe(func(e DLCEvent) { events = append(events, e) })
+
+	m.Install(10)
+	if !slices.Equal(apps.installed, []AppId_t{10}) {
+		t.Fatalf("InstallDLC calls=%v, want [10]", apps.installed)
+	}
+	apps.dlc[0].downloaded, apps.dlc[0].total = 50, 200
+	m.Update()
+	m.Update() // unchanged progress must not emit again
+	p, ok := m.Progress(10)
+	if !ok || p.Downloaded != 50 || p.Total != 200 || p.Fraction() != 0.25 {
+		t.Fatalf("Progress(10)=%+v, %v, want 50/200", p, ok)
+	}
+	if n := len(slices.Collect(m.Downloads())); n != 1 {
+		t.Fatalf("Downloads() len=%d, want 1", n)
+	}
+
+	apps.dlc[0].Installed = true
+	dispatchCallback(d, CallbackIDDlcInstalled, DlcInstalled{AppID: 10})
+	if _, ok := m.Progress(10); ok {
+		t.Fatalf("Progress(10) still tracked after install")
+	}
+	if got, _ := m.Get(10); !got.Installed {
+		t.Fatalf("Get(10).Installed=false, want true")
+	}
+
+	want := []DLCEventType{DLCEventProgress, DLCEventInstalled}
+	var got []DLCEventType
+	for _, e := range events {
+		got = append(got, e.Type)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("event types=%v, want %v", got, want)
+	}
+}
+
+func TestDLCManagerOwnershipChange(t *testing.T) {
+	apps := &fakeApps{dlc: []fakeDLC{{DLC: DLC{AppID: 10}}}}
+	d := NewCallbackDispatcher()
+	m := NewDLCManager(apps, d)
+
+	var events []DLCEvent
+	m.Subscribe(func(e DLCEvent) { events = append(events, e) })
+
+	apps.dlc[0].Available = true
+	dispatchCallback(d, CallbackIDAppProofOfPurchaseKeyResponse, AppProofOfPurchaseKeyResponse{Result: EResultOK, AppID: 10})
+	apps.dlc = append(apps.dlc, fakeDLC{DLC: DLC{AppID: 11, Available: true}})
+	dispatchCallback(d, CallbackIDNewUrlLaunchParameters, NewUrlLaunchParameters{})
+
+	if len(events) != 2 {
+		t.Fatalf("events=%+v, want 2 ownership changes", events)
+	}
+	for i, appID := range []AppId_t{10, 11} {
+		if events[i].Type != DLCEventOwnershipChanged || events[i].DLC.AppID != appID || !events[i].DLC.Available {
+			t.Fatalf("events[%d]=%+v, want ownership change for %d", i, events[i], appID)
+		}
+	}
+
+	m.Close()
+	apps.dlc[0].Available = false
+	dispatchCallback(d, CallbackIDNewUrlLaunchParameters, NewUrlLaunchParameters{})
+	if len(events) != 2 {
+		t.Fatalf("received %d events after Close, want 2", len(events))
+	}
+}
+
+func TestAppsCallbackPayloadSizes(t *testing.T) {
+	if got, want := unsafe.Sizeof(DlcInstalled{}), uintptr(4); got != want {
+		t.Fatalf("DlcInstalled size=%d, want %d", got, want)
+	}
+	if got, want := unsafe.Sizeof(AppProofOfPurchaseKeyResponse{}), uintptr(252); got != want {
+		t.Fatalf("AppProofOfPurchaseKeyResponse size=%d, want %d", got, want)
+	}
+}
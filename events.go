@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"slices"
+	"sync"
+)
+
+type eventSubscriber[E any] struct {
+	fn func(E)
+}
+
+// eventSource fans events out to subscribers in subscription order.
+type eventSource[E any] struct {
+	mu   sync.Mutex
+	subs []*eventSubscriber[E]
+}
+
+func (s *eventSource[E]) subscribe(fn func(E)) (unsubscribe func()) {
+	sub := &eventSubscriber[E]{fn: fn}
+	s.mu.Lock()
+	s.subs = append(slices.Clip(s.subs), sub)
+	s.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			s.subs = slices.DeleteFunc(slices.Clone(s.subs), func(c *eventSubscriber[E]) bool { return c == sub })
+			s.mu.Unlock()
+		})
+	}
+}
+
+func (s *eventSource[E]) emit(e E) {
+	s.mu.Lock()
+	subs := s.subs
+	s.mu.Unlock()
+	for _, sub := range subs {
+		sub.fn(e)
+	}
+}
@@ -4,8 +4,7 @@
 package main
 
 import (
-	"errors"
-	"image"
+	"context"
 	"image/png"
 	"log"
 	"os"
@@ -31,26 +30,22 @@ func main() {
 	personaName := steamworks.SteamFriends().GetPersonaName()
 	log.Printf("Signed in as %s (%d)", personaName, steamID)
 
-	avatarHandle, err := fetchLargeAvatarHandle(steamID, 5*time.Second)
-	if err != nil {
-		log.Fatalf("failed to get avatar handle: %v", err)
-	}
-	if avatarHandle == 0 {
-		log.Fatal("no avatar available for this user")
-	}
+	// LoadAvatar waits for Steam to deliver the image, so keep pumping callbacks.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go func() {
+		for ctx.Err() == nil {
+			steamworks.RunCallbacks()
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
 
-	width, height, ok := steamworks.SteamUtils().GetImageSize(int(avatarHandle))
-	if !ok {
-		log.Fatal("failed to get avatar image size")
-	}
-	pixels := make([]byte, int(width*height*4))
-	if ok := steamworks.SteamUtils().GetImageRGBA(int(avatarHandle), pixels); !ok {
-		log.Fatal("failed to read avatar image data")
+	avatars := steamworks.NewAvatarLoader(steamworks.SteamFriends(), steamworks.SteamUtils(), nil, 0)
+	img, err := avatars.LoadAvatar(ctx, steamID, steamworks.AvatarLarge)
+	if err != nil {
+		log.Fatalf("failed to load avatar: %v", err)
 	}
 
-	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
-	copy(img.Pix, pixels)
-
 	file, err := os.Create("avatar.png")
 	if err != nil {
 		log.Fatalf("failed to create avatar.png: %v", err)
@@ -63,20 +58,3 @@ func main() {
 
 	log.Printf("Saved avatar for %s to avatar.png", personaName)
 }
-
-func fetchLargeAvatarHandle(steamID steamworks.CSteamID, timeout time.Duration) (int32, error) {
-	deadline := time.Now().Add(timeout)
-	for {
-		avatar := steamworks.SteamFriends().GetLargeFriendAvatar(steamID)
-		if avatar != -1 {
-			return avatar, nil
-		}
-
-		if time.Now().After(deadline) {
-			return 0, errors.New("timed out waiting for avatar")
-		}
-
-		steamworks.RunCallbacks()
-		time.Sleep(50 * time.Millisecond)
-	}
-}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var (
+	ErrFileDetailsFailed = errors.New("steamworks: GetFileDetails call failed")
+	ErrInstallDirUnknown = errors.New("steamworks: app install directory unknown")
+	ErrInvalidFilePath   = errors.New("steamworks: file path escapes the install directory")
+)
+
+// FileDetailsResult is the typed result of ISteamApps.GetFileDetails
+// (FileDetailsResult_t).
+type FileDetailsResult struct {
+	Result   EResult
+	FileSize uint64
+	FileSHA  [sha1.Size]byte
+	Flags    uint32
+}
+
+func (p fileDetailsPayload) result() FileDetailsResult {
+	return FileDetailsResult{
+		Result:   p.Result,
+		FileSize: p.fileSize(),
+		FileSHA:  p.FileSHA,
+		Flags:    p.Flags,
+	}
+}
+
+// GetFileDetails requests the depot details of filename, relative to the app
+// install directory, and waits for the FileDetailsResult_t call result.
+// A pollInterval <= 0 uses the CallResult.Wait default.
+func GetFileDetails(ctx context.Context, apps ISteamApps, filename string, pollInterval time.Duration) (FileDetailsResult, error) {
+	call := apps.GetFileDetails(filename)
+	if call == 0 {
+		return FileDetailsResult{}, ErrFileDetailsFailed
+	}
+	payload, failed, err := NewCallResult[fileDetailsPayload](call, int32(CallbackIDFileDetailsResult)).Wait(ctx, pollInterval)
+	if err != nil {
+		return FileDetailsResult{}, err
+	}
+	if failed {
+		return FileDetailsResult{}, ErrFileDetailsFailed
+	}
+	details := payload.result()
+	if details.Result != EResultOK {
+		return details, fmt.Errorf("%w: result %d", ErrFileDetailsFailed, details.Result)
+	}
+	return details, nil
+}
+
+// VerifyLocalFile hashes filename under the install directory of appID and
+// reports whether its size and SHA-1 match details.
+func VerifyLocalFile(apps ISteamApps, appID AppId_t, filename string, details FileDetailsResult) (bool, error) {
+	dir := apps.GetAppInstallDir(appID)
+	if dir == "" {
+		return false, ErrInstallDirUnknown
+	}
+	rel := filepath.FromSlash(filename)
+	if !filepath.IsLocal(rel) {
+		return false, ErrInvalidFilePath
+	}
+
+	f, err := os.Open(filepath.Join(dir, rel))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	if uint64(info.Size()) != details.FileSize {
+		return false, nil
+	}
+	h := sha1.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false, err
+	}
+	var sum [sha1.Size]byte
+	h.Sum(sum[:0])
+	return sum == details.FileSHA, nil
+}
+
+// VerifyFile fetches the depot details of filename and verifies the local copy
+// under the install directory of appID. A false result with a nil error means
+// the file is missing or differs; callers may then call MarkContentCorrupt.
+func VerifyFile(ctx context.Context, apps ISteamApps, appID AppId_t, filename string) (bool, error) {
+	details, err := GetFileDetails(ctx, apps, filename, 0)
+	if err != nil {
+		return false, err
+	}
+	return VerifyLocalFile(apps, appID, filename, details)
+}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+//go:build !windows
+
+package steamworks
+
+// fileDetailsPayload mirrors FileDetailsResult_t under the 4-byte callback
+// packing Steam uses on Linux and macOS, which leaves the file size unaligned.
+type fileDetailsPayload struct {
+	Result     EResult
+	FileSizeLo uint32
+	FileSizeHi uint32
+	FileSHA    [20]byte
+	Flags      uint32
+}
+
+func (p fileDetailsPayload) fileSize() uint64 {
+	return uint64(p.FileSizeHi)<<32 | uint64(p.FileSizeLo)
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"crypto/sha1"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+type fakeInstallApps struct {
+	ISteamApps
+	dir string
+}
+
+func (f fakeInstallApps) GetAppInstallDir(AppId_t) string { return f.dir }
+
+func TestVerifyLocalFile(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte("game data")
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data", "pak0.bin"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	good := FileDetailsResult{Result: EResultOK, FileSize: uint64(len(content)), FileSHA: sha1.Sum(content)}
+	badSHA := good
+	badSHA.FileSHA[0] ^= 0xff
+	badSize := good
+	badSize.FileSize++
+
+	apps := fakeInstallApps{dir: dir}
+	tests := []struct {
+		name    string
+		file    string
+		details FileDetailsResult
+		want    bool
+		wantErr error
+	}{
+		{name: "match", file: "data/pak0.bin", details: good, want: true},
+		{name: "sha-mismatch", file: "data/pak0.bin", details: badSHA},
+		{name: "size-mismatch", file: "data/pak0.bin", details: badSize},
+		{name: "missing", file: "data/pak1.bin", details: good},
+		{name: "escape", file: "../pak0.bin", details: good, wantErr: ErrInvalidFilePath},
+	}
+	for _, tt := range tests {
+		got, err := VerifyLocalFile(apps, 480, tt.file, tt.details)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Fatalf("%s: VerifyLocalFile=%v, %v, want %v, %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	if _, err := VerifyLocalFile(fakeInstallApps{}, 480, "data/pak0.bin", good); !errors.Is(err, ErrInstallDirUnknown) {
+		t.Fatalf("error=%v, want %v", err, ErrInstallDirUnknown)
+	}
+}
+
+func TestFileDetailsPayloadLayout(t *testing.T) {
+	var p fileDetailsPayload
+	wantSize, wantSHA := uintptr(36), uintptr(12)
+	if runtime.GOOS == "windows" {
+		wantSize, wantSHA = 40, 16
+	}
+	if got := unsafe.Sizeof(p); got != wantSize {
+		t.Fatalf("fileDetailsPayload size=%d, want %d", got, wantSize)
+	}
+	if got := unsafe.Offsetof(p.FileSHA); got != wantSHA {
+		t.Fatalf("fileDetailsPayload.FileSHA offset=%d, want %d", got, wantSHA)
+	}
+
+	// Write the file size the way the SDK lays it out and check it decodes.
+	const size = 0x0102030405060708
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(&p)), unsafe.Sizeof(p))
+	putUint64(buf[wantSHA-8:], size)
+	if got := p.result().FileSize; got != size {
+		t.Fatalf("FileSize=%#x, want %#x", got, uint64(size))
+	}
+}
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+// fileDetailsPayload mirrors FileDetailsResult_t under Windows' 8-byte callback packing.
+type fileDetailsPayload struct {
+	Result   EResult
+	_        uint32
+	FileSize uint64
+	FileSHA  [20]byte
+	Flags    uint32
+}
+
+func (p fileDetailsPayload) fileSize() uint64 {
+	return p.FileSize
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"net/netip"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// ServerNetAddr is a game server address (servernetadr_t).
+type ServerNetAddr struct {
+	ConnectionPort uint16
+	QueryPort      uint16
+	// IP is the IPv4 address in host byte order.
+	IP uint32
+}
+
+// Addr returns the server's IPv4 address.
+func (a ServerNetAddr) Addr() netip.Addr {
+	return netip.AddrFrom4([4]byte{byte(a.IP >> 24), byte(a.IP >> 16), byte(a.IP >> 8), byte(a.IP)})
+}
+
+// ConnectionAddr returns the address game clients connect to.
+func (a ServerNetAddr) ConnectionAddr() netip.AddrPort {
+	return netip.AddrPortFrom(a.Addr(), a.ConnectionPort)
+}
+
+// QueryAddr returns the address answering server queries.
+func (a ServerNetAddr) QueryAddr() netip.AddrPort {
+	return netip.AddrPortFrom(a.Addr(), a.QueryPort)
+}
+
+// GameServerItem is a decoded gameserveritem_t. Steam does not report the
+// game data set with ISteamGameServer.SetGameData here; it can only be
+// filtered on.
+type GameServerItem struct {
+	Addr                  ServerNetAddr
+	Ping                  time.Duration
+	HadSuccessfulResponse bool
+	DoNotRefresh          bool
+	GameDir               string
+	Map                   string
+	GameDescription       string
+	AppID                 AppId_t
+	Players               int
+	MaxPlayers            int
+	BotPlayers            int
+	Password              bool
+	Secure                bool
+	// LastPlayed is when the local user last played on the server, for
+	// favorites and history lists; it is zero otherwise.
+	LastPlayed    time.Time
+	ServerVersion int
+	Name          string
+	Tags          []string
+	SteamID       CSteamID
+}
+
+// gameServerItemData mirrors gameserveritem_t. The SDK declares CSteamID with
+// 1-byte packing, so m_steamID sits unaligned at offset 364 on every platform
+// and is read as two halves.
+type gameServerItemData struct {
+	ConnectionPort        uint16
+	QueryPort             uint16
+	IP                    uint32
+	Ping                  int32
+	HadSuccessfulResponse bool
+	DoNotRefresh          bool
+	GameDir               [32]byte
+	Map                   [32]byte
+	GameDescription       [64]byte
+	AppID                 uint32
+	Players               int32
+	MaxPlayers            int32
+	BotPlayers            int32
+	Password              bool
+	Secure                bool
+	TimeLastPlayed        uint32
+	ServerVersion         int32
+	ServerName            [64]byte
+	GameTags              [128]byte
+	SteamIDLo             uint32
+	SteamIDHi             uint32
+}
+
+func (d *gameServerItemData) steamID() CSteamID {
+	return CSteamID(uint64(d.SteamIDHi)<<32 | uint64(d.SteamIDLo))
+}
+
+// Decode copies the server details out of Steam's memory. The item is only
+// valid until its server list is released or refreshed, or, for a ping
+// response, until the handler returns. Decode reports false for an invalid
+// item.
+func (i MatchmakingServerItem) Decode() (GameServerItem, bool) {
+	if !i.Valid() {
+		return GameServerItem{}, false
+	}
+	// Reinterpret the stored address rather than converting the uintptr,
+	// which points to memory owned by Steam.
+	data := *(**gameServerItemData)(unsafe.Pointer(&i.ptr))
+	return data.decode(), true
+}
+
+func (d *gameServerItemData) decode() GameServerItem {
+	item := GameServerItem{
+		Addr:                  ServerNetAddr{ConnectionPort: d.ConnectionPort, QueryPort: d.QueryPort, IP: d.IP},
+		Ping:                  time.Duration(d.Ping) * time.Millisecond,
+		HadSuccessfulResponse: d.HadSuccessfulResponse,
+		DoNotRefresh:          d.DoNotRefresh,
+		GameDir:               cStringToGo(d.GameDir[:]),
+		Map:                   cStringToGo(d.Map[:]),
+		GameDescription:       cStringToGo(d.GameDescription[:]),
+		AppID:                 AppId_t(d.AppID),
+		Players:               int(d.Players),
+		MaxPlayers:            int(d.MaxPlayers),
+		BotPlayers:            int(d.BotPlayers),
+		Password:              d.Password,
+		Secure:                d.Secure,
+		ServerVersion:         int(d.ServerVersion),
+		Name:                  cStringToGo(d.ServerName[:]),
+		SteamID:               d.steamID(),
+	}
+	if d.TimeLastPlayed != 0 {
+		item.LastPlayed = time.Unix(int64(d.TimeLastPlayed), 0)
+	}
+	if tags := cStringToGo(d.GameTags[:]); tags != "" {
+		item.Tags = strings.Split(tags, ",")
+	}
+	// Like gameserveritem_t::GetName, fall back to the address.
+	if item.Name == "" {
+		item.Name = item.Addr.ConnectionAddr().String()
+	}
+	return item
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestGameServerItemLayout(t *testing.T) {
+	var d gameServerItemData
+	offsets := []struct {
+		name string
+		got  uintptr
+		want uintptr
+	}{
+		{"m_NetAdr.m_unIP", unsafe.Offsetof(d.IP), 4},
+		{"m_nPing", unsafe.Offsetof(d.Ping), 8},
+		{"m_bHadSuccessfulResponse", unsafe.Offsetof(d.HadSuccessfulResponse), 12},
+		{"m_szGameDir", unsafe.Offsetof(d.GameDir), 14},
+		{"m_szMap", unsafe.Offsetof(d.Map), 46},
+		{"m_szGameDescription", unsafe.Offsetof(d.GameDescription), 78},
+		{"m_nAppID", unsafe.Offsetof(d.AppID), 144},
+		{"m_nPlayers", unsafe.Offsetof(d.Players), 148},
+		{"m_nBotPlayers", unsafe.Offsetof(d.BotPlayers), 156},
+		{"m_bPassword", unsafe.Offsetof(d.Password), 160},
+		{"m_ulTimeLastPlayed", unsafe.Offsetof(d.TimeLastPlayed), 164},
+		{"m_nServerVersion", unsafe.Offsetof(d.ServerVersion), 168},
+		{"m_szServerName", unsafe.Offsetof(d.ServerName), 172},
+		{"m_szGameTags", unsafe.Offsetof(d.GameTags), 236},
+		{"m_steamID", unsafe.Offsetof(d.SteamIDLo), 364},
+	}
+	for _, o := range offsets {
+		if o.got != o.want {
+			t.Errorf("%s offset=%d, want %d", o.name, o.got, o.want)
+		}
+	}
+	if got := unsafe.Sizeof(d); got != 372 {
+		t.Fatalf("gameserveritem_t size=%d, want 372", got)
+	}
+}
+
+// newGameServerItemData builds a gameserveritem_t as Steam would fill it.
+func newGameServerItemData() *gameServerItemData {
+	d := &gameServerItemData{}
+	d.ConnectionPort, d.QueryPort, d.IP = 27015, 27016, 0xC0A80105
+	d.Ping = 42
+	d.HadSuccessfulResponse = true
+	copy(d.GameDir[:], "cstrike")
+	copy(d.Map[:], "de_dust2")
+	copy(d.GameDescription[:], "Counter-Strike")
+	d.AppID = 10
+	d.Players, d.MaxPlayers, d.BotPlayers = 12, 24, 2
+	d.Secure = true
+	d.TimeLastPlayed = 1700000000
+	d.ServerVersion = 1337
+	copy(d.ServerName[:], "Friday Night Dust")
+	copy(d.GameTags[:], "secure,ctf")
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(d)), unsafe.Sizeof(*d))
+	putUint64(buf[364:], 0x0186000000000001)
+	return d
+}
+
+func TestMatchmakingServerItemDecode(t *testing.T) {
+	d := newGameServerItemData()
+	item, ok := MatchmakingServerItem{ptr: uintptr(unsafe.Pointer(d))}.Decode()
+	if !ok {
+		t.Fatalf("Decode reported an invalid item")
+	}
+	if got := item.Addr.ConnectionAddr().String(); got != "192.168.1.5:27015" {
+		t.Fatalf("connection address=%s", got)
+	}
+	if got := item.Addr.QueryAddr().String(); got != "192.168.1.5:27016" {
+		t.Fatalf("query address=%s", got)
+	}
+	if item.Ping != 42*time.Millisecond || item.Map != "de_dust2" || item.GameDir != "cstrike" || item.GameDescription != "Counter-Strike" {
+		t.Fatalf("item=%+v", item)
+	}
+	if item.AppID != 10 || item.Players != 12 || item.MaxPlayers != 24 || item.BotPlayers != 2 || item.Password || !item.Secure {
+		t.Fatalf("item=%+v", item)
+	}
+	if !item.LastPlayed.Equal(time.Unix(1700000000, 0)) || item.ServerVersion != 1337 || item.Name != "Friday Night Dust" {
+		t.Fatalf("item=%+v", item)
+	}
+	if !slices.Equal(item.Tags, []string{"secure", "ctf"}) || item.SteamID != 0x0186000000000001 {
+		t.Fatalf("tags=%q steamID=%#x", item.Tags, item.SteamID)
+	}
+
+	d.ServerName = [64]byte{}
+	if item, _ := (MatchmakingServerItem{ptr: uintptr(unsafe.Pointer(d))}).Decode(); item.Name != "192.168.1.5:27015" {
+		t.Fatalf("unnamed server Name=%q, want its address", item.Name)
+	}
+	if _, ok := (MatchmakingServerItem{}).Decode(); ok {
+		t.Fatalf("Decode of a null item reported ok")
+	}
+}
+
+func TestPingGameServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	f := &fakeServerQueries{server: newGameServerItemData()}
+	item, err := pingGameServer(ctx, f, 0xC0A80105, 27016)
+	if err != nil || item.Map != "de_dust2" || item.SteamID != 0x0186000000000001 {
+		t.Fatalf("pingGameServer=%+v, %v", item, err)
+	}
+	f.server = nil
+	if _, err := pingGameServer(ctx, f, 0xC0A80105, 27016); !errors.Is(err, ErrServerQueryFailed) {
+		t.Fatalf("unanswered ping error=%v, want %v", err, ErrServerQueryFailed)
+	}
+}
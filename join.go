@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"strings"
+	"sync"
+)
+
+// JoinSource identifies where a JoinRequest came from.
+type JoinSource int
+
+const (
+	// JoinSourceCommandLine is a "+connect_lobby" or "+connect" launch argument,
+	// either from a cold start or a NewUrlLaunchParameters_t.
+	JoinSourceCommandLine JoinSource = iota
+	// JoinSourceLobby is a GameLobbyJoinRequested_t.
+	JoinSourceLobby
+	// JoinSourceRichPresence is a GameRichPresenceJoinRequested_t.
+	JoinSourceRichPresence
+)
+
+// JoinRequest asks the game to join a friend, either through a lobby or a
+// connect string.
+type JoinRequest struct {
+	Source JoinSource
+	// LobbyID is set when the request targets a lobby.
+	LobbyID CSteamID
+	// Connect is the server address or the friend's "connect" rich presence
+	// value when the request does not target a lobby.
+	Connect string
+	// Friend is the inviting friend. Steam does not report it for command line
+	// joins, where it is 0.
+	Friend CSteamID
+}
+
+// IsLobby reports whether the request targets a lobby.
+func (r JoinRequest) IsLobby() bool {
+	return r.LobbyID != 0
+}
+
+// JoinRequests merges GameLobbyJoinRequested_t, GameRichPresenceJoinRequested_t
+// and "+connect_lobby"/"+connect" launch arguments into one event stream, so
+// joining a friend works whether the game was cold-started or already running.
+type JoinRequests struct {
+	mu      sync.Mutex
+	initial JoinRequest
+	pending bool
+
+	events  eventSource[JoinRequest]
+	removes []func()
+}
+
+// NewJoinRequests constructs the component from a launch monitor, whose
+// current parameters provide the cold-start request, and subscribes to the
+// join callbacks on d. launch and d may be nil.
+func NewJoinRequests(launch *LaunchMonitor, d *CallbackDispatcher) *JoinRequests {
+	j := &JoinRequests{}
+	if launch != nil {
+		j.initial, j.pending = joinRequestFromLaunch(launch.Current())
+		j.removes = append(j.removes, launch.Subscribe(j.onLaunchParams))
+	}
+	if d != nil {
+		j.removes = append(j.removes,
+			AddCallback(d, CallbackIDGameLobbyJoinRequested, j.onLobbyJoinRequested),
+			AddCallback(d, CallbackIDGameRichPresenceJoinRequested, j.onRichPresenceJoinRequested),
+		)
+	}
+	return j
+}
+
+// Close unsubscribes the component from its launch monitor and dispatcher.
+func (j *JoinRequests) Close() {
+	for _, remove := range j.removes {
+		remove()
+	}
+	j.removes = nil
+}
+
+// Initial returns the join request the game was launched with, if any. It
+// reports it only once so a cold-start join is not repeated.
+func (j *JoinRequests) Initial() (JoinRequest, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	r, ok := j.initial, j.pending
+	j.initial, j.pending = JoinRequest{}, false
+	return r, ok
+}
+
+// Subscribe registers fn for join requests received while the game is
+// running. Calling the returned function unsubscribes it.
+func (j *JoinRequests) Subscribe(fn func(JoinRequest)) (unsubscribe func()) {
+	return j.events.subscribe(fn)
+}
+
+func (j *JoinRequests) onLaunchParams(p LaunchParams) {
+	if r, ok := joinRequestFromLaunch(p); ok {
+		j.events.emit(r)
+	}
+}
+
+func (j *JoinRequests) onLobbyJoinRequested(cb GameLobbyJoinRequested) {
+	j.events.emit(JoinRequest{Source: JoinSourceLobby, LobbyID: cb.SteamIDLobby, Friend: cb.SteamIDFriend})
+}
+
+// onRichPresenceJoinRequested decodes connect strings that carry launch
+// arguments, such as "+connect_lobby <id>", the same way as the command line.
+func (j *JoinRequests) onRichPresenceJoinRequested(cb GameRichPresenceJoinRequested) {
+	connect := strings.TrimSpace(cb.ConnectString())
+	r := JoinRequest{Source: JoinSourceRichPresence, Connect: connect, Friend: cb.SteamIDFriend}
+	if p := ParseLaunchCommandLine(connect); p.ConnectLobby != 0 {
+		r.LobbyID, r.Connect = p.ConnectLobby, ""
+	} else if p.Connect != "" {
+		r.Connect = p.Connect
+	}
+	j.events.emit(r)
+}
+
+func joinRequestFromLaunch(p LaunchParams) (JoinRequest, bool) {
+	switch {
+	case p.ConnectLobby != 0:
+		return JoinRequest{Source: JoinSourceCommandLine, LobbyID: p.ConnectLobby}, true
+	case p.Connect != "":
+		return JoinRequest{Source: JoinSourceCommandLine, Connect: p.Connect}, true
+	}
+	return JoinRequest{}, false
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func richPresenceJoin(friend CSteamID, connect string) GameRichPresenceJoinRequested {
+	cb := GameRichPresenceJoinRequested{SteamIDFriend: friend}
+	copy(cb.Connect[:], connect)
+	return cb
+}
+
+func TestJoinRequests(t *testing.T) {
+	apps := &fakeLaunchApps{cmdline: "+connect_lobby 42"}
+	d := NewCallbackDispatcher()
+	launch := NewLaunchMonitor(apps, d, nil)
+	defer launch.Close()
+	j := NewJoinRequests(launch, d)
+	defer j.Close()
+
+	if r, ok := j.Initial(); !ok || r.Source != JoinSourceCommandLine || r.LobbyID != 42 {
+		t.Fatalf("Initial()=%+v,%v, want command line lobby 42", r, ok)
+	}
+	if _, ok := j.Initial(); ok {
+		t.Fatalf("Initial() reported the cold-start request twice")
+	}
+
+	var got []JoinRequest
+	j.Subscribe(func(r JoinRequest) { got = append(got, r) })
+
+	dispatchCallback(d, CallbackIDGameLobbyJoinRequested, GameLobbyJoinRequested{SteamIDLobby: 100, SteamIDFriend: 7})
+	dispatchCallback(d, CallbackIDGameRichPresenceJoinRequested, richPresenceJoin(8, "+connect 10.0.0.1:27015"))
+	dispatchCallback(d, CallbackIDGameRichPresenceJoinRequested, richPresenceJoin(9, "+connect_lobby 101"))
+	dispatchCallback(d, CallbackIDGameRichPresenceJoinRequested, richPresenceJoin(10, "match=abc"))
+	apps.cmdline = "-windowed"
+	dispatchCallback(d, CallbackIDNewUrlLaunchParameters, NewUrlLaunchParameters{})
+	apps.cmdline = "+connect 10.0.0.2:27015"
+	dispatchCallback(d, CallbackIDNewUrlLaunchParameters, NewUrlLaunchParameters{})
+
+	want := []JoinRequest{
+		{Source: JoinSourceLobby, LobbyID: 100, Friend: 7},
+		{Source: JoinSourceRichPresence, Connect: "10.0.0.1:27015", Friend: 8},
+		{Source: JoinSourceRichPresence, LobbyID: 101, Friend: 9},
+		{Source: JoinSourceRichPresence, Connect: "match=abc", Friend: 10},
+		{Source: JoinSourceCommandLine, Connect: "10.0.0.2:27015"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("events=%+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("event %d=%+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if !got[0].IsLobby() || got[1].IsLobby() {
+		t.Fatalf("IsLobby mismatch: %+v", got[:2])
+	}
+}
+
+func TestJoinRequestedLayout(t *testing.T) {
+	if got, want := unsafe.Sizeof(GameLobbyJoinRequested{}), uintptr(16); got != want {
+		t.Fatalf("GameLobbyJoinRequested size=%d, want %d", got, want)
+	}
+	if got, want := unsafe.Sizeof(GameRichPresenceJoinRequested{}), uintptr(264); got != want {
+		t.Fatalf("GameRichPresenceJoinRequested size=%d, want %d", got, want)
+	}
+}
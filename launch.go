@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const steamRunPrefix = "steam://run/"
+
+// LaunchParams is a parsed game launch command line.
+type LaunchParams struct {
+	// Raw is the command line the parameters were parsed from.
+	Raw string
+	// AppID is set when the command line was a steam://run/<appid> URL.
+	AppID AppId_t
+	// Args holds the tokenized arguments.
+	Args []string
+	// Options maps "+name" and "-name" arguments, without their prefix, to the
+	// space-joined arguments that follow them. Flags without arguments map to "".
+	Options map[string]string
+	// Query holds query parameters of a steam://run URL.
+	Query url.Values
+	// Connect is the server address from "+connect <address>".
+	Connect string
+	// ConnectLobby is the lobby from "+connect_lobby <id>".
+	ConnectLobby CSteamID
+}
+
+// Get returns the value of a "+name" or "-name" option.
+func (p LaunchParams) Get(name string) (string, bool) {
+	v, ok := p.Options[name]
+	return v, ok
+}
+
+// Has reports whether a "+name" or "-name" option is present.
+func (p LaunchParams) Has(name string) bool {
+	_, ok := p.Options[name]
+	return ok
+}
+
+// IsEmpty reports whether no arguments were parsed.
+func (p LaunchParams) IsEmpty() bool {
+	return len(p.Args) == 0 && len(p.Query) == 0
+}
+
+// ParseLaunchCommandLine parses a command line as returned by
+// GetLaunchCommandLine, or a steam://run/<appid>/<language>/<args> URL.
+func ParseLaunchCommandLine(cmdline string) LaunchParams {
+	cmdline = strings.TrimSpace(strings.TrimRight(cmdline, "\x00"))
+	if strings.HasPrefix(cmdline, steamRunPrefix) {
+		return parseSteamRunURL(cmdline)
+	}
+	p := ParseLaunchArgs(splitCommandLine(cmdline))
+	p.Raw = cmdline
+	return p
+}
+
+// ParseLaunchArgs parses already tokenized arguments, such as os.Args[1:].
+// A single steam://run URL argument is expanded like ParseLaunchCommandLine.
+func ParseLaunchArgs(args []string) LaunchParams {
+	if len(args) == 1 && strings.HasPrefix(args[0], steamRunPrefix) {
+		return parseSteamRunURL(args[0])
+	}
+	p := LaunchParams{
+		Raw:     strings.Join(args, " "),
+		Args:    args,
+		Options: make(map[string]string),
+	}
+	for i := 0; i < len(args); i++ {
+		name, ok := optionName(args[i])
+		if !ok {
+			continue
+		}
+		j := i + 1
+		for j < len(args) {
+			if _, next := optionName(args[j]); next {
+				break
+			}
+			j++
+		}
+		p.Options[name] = strings.Join(args[i+1:j], " ")
+		i = j - 1
+	}
+
+	p.Connect = p.Options["connect"]
+	if lobby, err := strconv.ParseUint(p.Options["connect_lobby"], 10, 64); err == nil {
+		p.ConnectLobby = CSteamID(lobby)
+	}
+	return p
+}
+
+// parseSteamRunURL handles steam://run/<appid>/<language>/<args>, where args
+// are URL encoded and may be followed by a query string.
+func parseSteamRunURL(raw string) LaunchParams {
+	rest := strings.TrimPrefix(raw, steamRunPrefix)
+	var query url.Values
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		query, _ = url.ParseQuery(rest[i+1:])
+		rest = rest[:i]
+	}
+
+	parts := strings.SplitN(rest, "/", 3)
+	var args string
+	if len(parts) == 3 {
+		args = parts[2]
+		if decoded, err := url.PathUnescape(args); err == nil {
+			args = decoded
+		}
+	}
+	p := ParseLaunchArgs(splitCommandLine(strings.TrimSuffix(args, "/")))
+	p.Raw = raw
+	p.Query = query
+	if appID, err := strconv.ParseUint(parts[0], 10, 32); err == nil {
+		p.AppID = AppId_t(appID)
+	}
+	if p.Connect == "" {
+		p.Connect = query.Get("connect")
+	}
+	return p
+}
+
+// optionName reports whether arg is a "+name" or "-name" option. Negative
+// numbers are treated as values.
+func optionName(arg string) (string, bool) {
+	if len(arg) < 2 || (arg[0] != '+' && arg[0] != '-') {
+		return "", false
+	}
+	name := strings.TrimLeft(arg, "+-")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '.' {
+		return "", false
+	}
+	return name, true
+}
+
+// splitCommandLine splits on whitespace, keeping double-quoted runs together.
+func splitCommandLine(s string) []string {
+	var (
+		args    []string
+		cur     strings.Builder
+		inQuote bool
+		inArg   bool
+	)
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			inArg = true
+		case !inQuote && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args
+}
+
+// LaunchMonitor tracks the game's launch parameters and re-reads them from
+// GetLaunchCommandLine whenever Steam delivers NewUrlLaunchParameters_t while
+// the game is running.
+type LaunchMonitor struct {
+	apps ISteamApps
+
+	mu      sync.Mutex
+	current LaunchParams
+
+	events eventSource[LaunchParams]
+	remove func()
+}
+
+// NewLaunchMonitor reads the initial parameters from Steam, falling back to
+// args (typically os.Args[1:]) when Steam reports no launch command line, and
+// subscribes to NewUrlLaunchParameters_t on d. d may be nil.
+func NewLaunchMonitor(apps ISteamApps, d *CallbackDispatcher, args []string) *LaunchMonitor {
+	m := &LaunchMonitor{apps: apps}
+	m.current = ParseLaunchCommandLine(apps.GetLaunchCommandLine(0))
+	if m.current.IsEmpty() {
+		m.current = ParseLaunchArgs(args)
+	}
+	if d != nil {
+		m.remove = AddCallback(d, CallbackIDNewUrlLaunchParameters, func(NewUrlLaunchParameters) { m.reload() })
+	}
+	return m
+}
+
+// Close unsubscribes the monitor from its dispatcher.
+func (m *LaunchMonitor) Close() {
+	if m.remove != nil {
+		m.remove()
+	}
+}
+
+// Current returns the most recent launch parameters.
+func (m *LaunchMonitor) Current() LaunchParams {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current
+}
+
+// QueryParam returns a steam://run query parameter via GetLaunchQueryParam.
+func (m *LaunchMonitor) QueryParam(key string) string {
+	return m.apps.GetLaunchQueryParam(key)
+}
+
+// Subscribe registers fn for parameters delivered mid-session. Calling the
+// returned function unsubscribes it.
+func (m *LaunchMonitor) Subscribe(fn func(LaunchParams)) (unsubscribe func()) {
+	return m.events.subscribe(fn)
+}
+
+func (m *LaunchMonitor) reload() {
+	p := ParseLaunchCommandLine(m.apps.GetLaunchCommandLine(0))
+	if p.Connect == "" {
+		p.Connect = m.apps.GetLaunchQueryParam("connect")
+	}
+	m.mu.Lock()
+	m.current = p
+	m.mu.Unlock()
+	m.events.emit(p)
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestParseLaunchCommandLine(t *testing.T) {
+	tests := []struct {
+		name         string
+		in           string
+		appID        AppId_t
+		args         []string
+		options      map[string]string
+		connect      string
+		connectLobby CSteamID
+	}{
+		{
+			name:    "connect",
+			in:      "+connect 10.0.0.1:27015 +password \"hunter two\" -windowed",
+			args:    []string{"+connect", "10.0.0.1:27015", "+password", "hunter two", "-windowed"},
+			options: map[string]string{"connect": "10.0.0.1:27015", "password": "hunter two", "windowed": ""},
+			connect: "10.0.0.1:27015",
+		},
+		{
+			name:         "connect-lobby",
+			in:           "+connect_lobby 109775241021923456\x00",
+			args:         []string{"+connect_lobby", "109775241021923456"},
+			options:      map[string]string{"connect_lobby": "109775241021923456"},
+			connectLobby: 109775241021923456,
+		},
+		{
+			name:    "negative-value",
+			in:      "-volume -5 --skip-intro",
+			args:    []string{"-volume", "-5", "--skip-intro"},
+			options: map[string]string{"volume": "-5", "skip-intro": ""},
+		},
+		{
+			name:    "run-url",
+			in:      "steam://run/480//+connect%20192.168.1.2:27015%20-novid/",
+			appID:   480,
+			args:    []string{"+connect", "192.168.1.2:27015", "-novid"},
+			options: map[string]string{"connect": "192.168.1.2:27015", "novid": ""},
+			connect: "192.168.1.2:27015",
+		},
+		{
+			name:    "run-url-query",
+			in:      "steam://run/480/english/?connect=1.2.3.4:27015",
+			appID:   480,
+			options: map[string]string{},
+			connect: "1.2.3.4:27015",
+		},
+		{
+			name:    "empty",
+			in:      "",
+			options: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		got := ParseLaunchCommandLine(tt.in)
+		if got.AppID != tt.appID {
+			t.Fatalf("%s: AppID=%d, want %d", tt.name, got.AppID, tt.appID)
+		}
+		if !slices.Equal(got.Args, tt.args) {
+			t.Fatalf("%s: Args=%q, want %q", tt.name, got.Args, tt.args)
+		}
+		if !maps.Equal(got.Options, tt.options) {
+			t.Fatalf("%s: Options=%v, want %v", tt.name, got.Options, tt.options)
+		}
+		if got.Connect != tt.connect || got.ConnectLobby != tt.connectLobby {
+			t.Fatalf("%s: Connect=%q ConnectLobby=%d, want %q %d", tt.name, got.Connect, got.ConnectLobby, tt.connect, tt.connectLobby)
+		}
+	}
+}
+
+type fakeLaunchApps struct {
+	ISteamApps
+	cmdline string
+	query   map[string]string
+}
+
+func (f *fakeLaunchApps) GetLaunchCommandLine(int) string       { return f.cmdline }
+func (f *fakeLaunchApps) GetLaunchQueryParam(key string) string { return f.query[key] }
+
+func TestLaunchMonitor(t *testing.T) {
+	apps := &fakeLaunchApps{}
+	d := NewCallbackDispatcher()
+	m := NewLaunchMonitor(apps, d, []string{"-windowed"})
+	defer m.Close()
+
+	if !m.Current().Has("windowed") {
+		t.Fatalf("Current()=%+v, want fallback args", m.Current())
+	}
+
+	var got []LaunchParams
+	m.Subscribe(func(p LaunchParams) { got = append(got, p) })
+
+	apps.cmdline = "+connect_lobby 42"
+	dispatchCallback(d, CallbackIDNewUrlLaunchParameters, NewUrlLaunchParameters{})
+	apps.cmdline = ""
+	apps.query = map[string]string{"connect": "10.0.0.1:27015"}
+	dispatchCallback(d, CallbackIDNewUrlLaunchParameters, NewUrlLaunchParameters{})
+
+	if len(got) != 2 || got[0].ConnectLobby != 42 || got[1].Connect != "10.0.0.1:27015" {
+		t.Fatalf("events=%+v, want lobby 42 then connect 10.0.0.1:27015", got)
+	}
+	if m.Current().Connect != "10.0.0.1:27015" {
+		t.Fatalf("Current().Connect=%q, want %q", m.Current().Connect, "10.0.0.1:27015")
+	}
+}
@@ -0,0 +1,531 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// lobbyJoinPollInterval is how often JoinLobbyAsync checks whether the join
+// call failed while it waits for the LobbyEnter callback.
+const lobbyJoinPollInterval = 50 * time.Millisecond
+
+// lobbyChatEntryMax is the largest lobby chat message Steam delivers.
+const lobbyChatEntryMax = 4096
+
+var (
+	ErrLobbyDataRejected = errors.New("steamworks: lobby data update rejected")
+	ErrLobbyChatRejected = errors.New("steamworks: lobby chat message rejected")
+	ErrLobbyCreateFailed = errors.New("steamworks: lobby creation failed")
+	ErrLobbyJoinFailed   = errors.New("steamworks: joining lobby failed")
+
+	// The errors below wrap ErrLobbyJoinFailed.
+	ErrLobbyNotFound       = fmt.Errorf("%w: lobby does not exist", ErrLobbyJoinFailed)
+	ErrLobbyFull           = fmt.Errorf("%w: lobby is full", ErrLobbyJoinFailed)
+	ErrLobbyBanned         = fmt.Errorf("%w: banned from lobby", ErrLobbyJoinFailed)
+	ErrLobbyLimitedAccount = fmt.Errorf("%w: limited user accounts cannot join lobbies", ErrLobbyJoinFailed)
+	ErrLobbyNotAllowed     = fmt.Errorf("%w: not allowed to join lobby", ErrLobbyJoinFailed)
+)
+
+// LobbyEnterResult is the typed result of joining a lobby (LobbyEnter_t).
+type LobbyEnterResult struct {
+	LobbyID         CSteamID
+	Locked          bool
+	ChatPermissions uint32
+	Response        EChatRoomEnterResponse
+}
+
+// CreateLobbyAsync creates a lobby, waits for the LobbyCreated_t call result
+// and returns the new lobby's ID. The local user enters it on success.
+func CreateLobbyAsync(ctx context.Context, mm ISteamMatchmaking, lobbyType ELobbyType, maxMembers int) (CSteamID, error) {
+	call := mm.CreateLobby(lobbyType, maxMembers)
+	if call == 0 {
+		return 0, ErrLobbyCreateFailed
+	}
+	payload, failed, err := NewCallResult[lobbyCreatedPayload](call, int32(CallbackIDLobbyCreated)).Wait(ctx, 0)
+	if err != nil {
+		return 0, err
+	}
+	if failed {
+		return 0, ErrLobbyCreateFailed
+	}
+	if payload.Result != EResultOK {
+		return 0, fmt.Errorf("%w: result %d", ErrLobbyCreateFailed, payload.Result)
+	}
+	return payload.steamIDLobby(), nil
+}
+
+// JoinLobbyAsync joins lobbyID and waits until Steam reports the outcome.
+// Steam posts LobbyEnter_t both as the call result and as a callback; when d
+// is non-nil the join completes from the callback, otherwise from the call
+// result, so it is never handled twice. With d, the call result is still
+// polled so that a join failing without a callback returns
+// ErrLobbyJoinFailed. Refused joins return one of the errors wrapping
+// ErrLobbyJoinFailed along with the result.
+func JoinLobbyAsync(ctx context.Context, mm ISteamMatchmaking, d *CallbackDispatcher, lobbyID CSteamID) (LobbyEnterResult, error) {
+	return joinLobbyAsync(ctx, mm, d, lobbyID, func(call SteamAPICall_t) (bool, bool) {
+		return NewCallResult[lobbyEnterPayload](call, int32(CallbackIDLobbyEnter)).IsComplete()
+	})
+}
+
+// joinLobbyAsync is JoinLobbyAsync with the check for the JoinLobby call
+// completing passed in.
+func joinLobbyAsync(ctx context.Context, mm ISteamMatchmaking, d *CallbackDispatcher, lobbyID CSteamID, completed func(SteamAPICall_t) (failed, ok bool)) (LobbyEnterResult, error) {
+	var entered chan LobbyEnter
+	if d != nil {
+		entered = make(chan LobbyEnter, 1)
+		remove := AddCallback(d, CallbackIDLobbyEnter, func(cb LobbyEnter) {
+			if cb.SteamIDLobby != lobbyID {
+				return
+			}
+			select {
+			case entered <- cb:
+			default:
+			}
+		})
+		defer remove()
+	}
+
+	call := mm.JoinLobby(lobbyID)
+	if call == 0 {
+		return LobbyEnterResult{}, ErrLobbyJoinFailed
+	}
+	var enter LobbyEnter
+	if entered != nil {
+		var err error
+		if enter, err = awaitLobbyEnter(ctx, entered, func() bool {
+			failed, ok := completed(call)
+			return ok && failed
+		}); err != nil {
+			return LobbyEnterResult{}, err
+		}
+	} else {
+		payload, failed, err := NewCallResult[lobbyEnterPayload](call, int32(CallbackIDLobbyEnter)).Wait(ctx, 0)
+		if err != nil {
+			return LobbyEnterResult{}, err
+		}
+		if failed {
+			return LobbyEnterResult{}, ErrLobbyJoinFailed
+		}
+		enter = payload.enter()
+	}
+
+	result := LobbyEnterResult{
+		LobbyID:         enter.SteamIDLobby,
+		Locked:          enter.Locked,
+		ChatPermissions: enter.ChatPermissions,
+		Response:        enter.ChatRoomEnterResponse,
+	}
+	return result, lobbyEnterError(enter.ChatRoomEnterResponse)
+}
+
+// awaitLobbyEnter waits for the LobbyEnter callback, checking callFailed
+// every lobbyJoinPollInterval so that a failed join does not wait forever.
+func awaitLobbyEnter(ctx context.Context, entered <-chan LobbyEnter, callFailed func() bool) (LobbyEnter, error) {
+	ticker := time.NewTicker(lobbyJoinPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return LobbyEnter{}, ctx.Err()
+		case enter := <-entered:
+			return enter, nil
+		case <-ticker.C:
+			select {
+			case enter := <-entered:
+				return enter, nil
+			default:
+			}
+			if callFailed() {
+				return LobbyEnter{}, ErrLobbyJoinFailed
+			}
+		}
+	}
+}
+
+func lobbyEnterError(response EChatRoomEnterResponse) error {
+	switch response {
+	case EChatRoomEnterResponseSuccess:
+		return nil
+	case EChatRoomEnterResponseDoesntExist:
+		return ErrLobbyNotFound
+	case EChatRoomEnterResponseFull:
+		return ErrLobbyFull
+	case EChatRoomEnterResponseBanned, EChatRoomEnterResponseCommunityBan:
+		return ErrLobbyBanned
+	case EChatRoomEnterResponseLimited:
+		return ErrLobbyLimitedAccount
+	case EChatRoomEnterResponseNotAllowed, EChatRoomEnterResponseClanDisabled,
+		EChatRoomEnterResponseMemberBlockedYou, EChatRoomEnterResponseYouBlockedMember:
+		return ErrLobbyNotAllowed
+	default:
+		return fmt.Errorf("%w: enter response %d", ErrLobbyJoinFailed, response)
+	}
+}
+
+// LobbyEventType identifies the kind of LobbyEvent.
+type LobbyEventType int
+
+const (
+	LobbyMemberJoined LobbyEventType = iota
+	LobbyMemberLeft
+	LobbyOwnerChanged
+	LobbyDataChanged
+	LobbyMemberDataChanged
+	LobbyChatReceived
+)
+
+// LobbyEvent reports a change to a Lobby's state.
+type LobbyEvent struct {
+	Type LobbyEventType
+	// Member is the member that joined or left, the new owner, the member
+	// whose data changed, or the chat sender.
+	Member CSteamID
+	// StateChange is set for LobbyMemberJoined and LobbyMemberLeft events.
+	StateChange EChatMemberStateChange
+	// Keys lists changed and deleted keys of LobbyDataChanged and
+	// LobbyMemberDataChanged events.
+	Keys []string
+	// ChatEntryType and ChatData are set for LobbyChatReceived events.
+	ChatEntryType EChatEntryType
+	ChatData      []byte
+}
+
+// Lobby keeps the member set, owner, lobby data and member data of a joined
+// lobby up to date from LobbyDataUpdate_t, LobbyChatUpdate_t and
+// LobbyChatMsg_t callbacks, and reports changes as LobbyEvent values.
+type Lobby struct {
+	mm         ISteamMatchmaking
+	id         CSteamID
+	memberKeys []string
+
+	mu         sync.Mutex
+	owner      CSteamID
+	members    []CSteamID
+	data       map[string]string
+	memberData map[CSteamID]map[string]string
+
+	events  eventSource[LobbyEvent]
+	removes []func()
+}
+
+// NewLobby wraps a lobby the local user created or joined and subscribes to
+// its callbacks on d. Steam cannot enumerate member data, so memberKeys lists
+// the member data keys the lobby tracks. d may be nil.
+func NewLobby(mm ISteamMatchmaking, d *CallbackDispatcher, lobbyID CSteamID, memberKeys ...string) *Lobby {
+	l := newLobby(mm, d, memberKeys)
+	l.enter(lobbyID)
+	return l
+}
+
+// CreateLobby creates a lobby like CreateLobbyAsync and returns it as a Lobby
+// tracking memberKeys. The Lobby subscribes to d before the lobby is created,
+// so no update after entering it is missed.
+func CreateLobby(ctx context.Context, mm ISteamMatchmaking, d *CallbackDispatcher, lobbyType ELobbyType, maxMembers int, memberKeys ...string) (*Lobby, error) {
+	l := newLobby(mm, d, memberKeys)
+	lobbyID, err := CreateLobbyAsync(ctx, mm, lobbyType, maxMembers)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	l.enter(lobbyID)
+	return l, nil
+}
+
+// JoinLobby joins lobbyID like JoinLobbyAsync and returns it as a Lobby
+// tracking memberKeys. The Lobby subscribes to d before joining, so no update
+// after entering the lobby is missed.
+func JoinLobby(ctx context.Context, mm ISteamMatchmaking, d *CallbackDispatcher, lobbyID CSteamID, memberKeys ...string) (*Lobby, error) {
+	l := newLobby(mm, d, memberKeys)
+	result, err := JoinLobbyAsync(ctx, mm, d, lobbyID)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	l.enter(result.LobbyID)
+	return l, nil
+}
+
+// newLobby returns a Lobby subscribed to d that ignores callbacks until enter
+// sets its ID.
+func newLobby(mm ISteamMatchmaking, d *CallbackDispatcher, memberKeys []string) *Lobby {
+	l := &Lobby{
+		mm:         mm,
+		memberKeys: slices.Clone(memberKeys),
+		data:       make(map[string]string),
+		memberData: make(map[CSteamID]map[string]string),
+	}
+	if d != nil {
+		l.removes = append(l.removes,
+			AddCallback(d, CallbackIDLobbyDataUpdate, l.onDataUpdate),
+			AddCallback(d, CallbackIDLobbyChatUpdate, l.onChatUpdate),
+			AddCallback(d, CallbackIDLobbyChatMsg, l.onChatMsg),
+		)
+	}
+	return l
+}
+
+// enter sets the lobby's ID and seeds its state from Steam, which already
+// reflects the callbacks ignored before. Callbacks arriving meanwhile wait on
+// l.mu and apply on top of the seeded state.
+func (l *Lobby) enter(lobbyID CSteamID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.id = lobbyID
+	l.owner = l.mm.GetLobbyOwner(lobbyID)
+	l.members = slices.Collect(l.mm.LobbyMembers(lobbyID))
+	l.data = readLobbyData(l.mm, lobbyID)
+	for _, member := range l.members {
+		l.memberData[member] = l.readMemberData(member)
+	}
+}
+
+// isLobby reports whether a callback for lobbyID concerns l. Callbacks only
+// read l.id after this, so they see the ID enter set.
+func (l *Lobby) isLobby(lobbyID CSteamID) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.id != 0 && l.id == lobbyID
+}
+
+// ID returns the lobby's Steam ID.
+func (l *Lobby) ID() CSteamID {
+	return l.id
+}
+
+// Owner returns the lobby owner.
+func (l *Lobby) Owner() CSteamID {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.owner
+}
+
+// Members returns the lobby members in join order.
+func (l *Lobby) Members() []CSteamID {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.members)
+}
+
+// IsMember reports whether user is in the lobby.
+func (l *Lobby) IsMember(user CSteamID) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Contains(l.members, user)
+}
+
+// Data returns a copy of the lobby data.
+func (l *Lobby) Data() map[string]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return maps.Clone(l.data)
+}
+
+// Get returns a lobby data value.
+func (l *Lobby) Get(key string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	v, ok := l.data[key]
+	return v, ok
+}
+
+// MemberData returns a tracked member data value of member.
+func (l *Lobby) MemberData(member CSteamID, key string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	v, ok := l.memberData[member][key]
+	return v, ok
+}
+
+// SetData sets a lobby data value. Only the owner can set lobby data; the
+// change is reported once Steam confirms it with LobbyDataUpdate_t.
+func (l *Lobby) SetData(key, value string) error {
+	if !l.mm.SetLobbyData(l.id, key, value) {
+		return ErrLobbyDataRejected
+	}
+	return nil
+}
+
+// DeleteData removes a lobby data value.
+func (l *Lobby) DeleteData(key string) error {
+	if !l.mm.DeleteLobbyData(l.id, key) {
+		return ErrLobbyDataRejected
+	}
+	return nil
+}
+
+// SetMemberData sets a member data value of the local user.
+func (l *Lobby) SetMemberData(key, value string) {
+	l.mm.SetLobbyMemberData(l.id, key, value)
+}
+
+// SendChat broadcasts data to every member, including the local user.
+func (l *Lobby) SendChat(data []byte) error {
+	if !l.mm.SendLobbyChatMsg(l.id, data) {
+		return ErrLobbyChatRejected
+	}
+	return nil
+}
+
+// Subscribe registers fn for lobby events. Calling the returned function
+// unsubscribes it.
+func (l *Lobby) Subscribe(fn func(LobbyEvent)) (unsubscribe func()) {
+	return l.events.subscribe(fn)
+}
+
+// Close unsubscribes the lobby from its dispatcher without leaving it.
+func (l *Lobby) Close() {
+	for _, remove := range l.removes {
+		remove()
+	}
+	l.removes = nil
+}
+
+// Leave leaves the lobby and closes it.
+func (l *Lobby) Leave() {
+	l.Close()
+	l.mm.LeaveLobby(l.id)
+}
+
+func (l *Lobby) readMemberData(member CSteamID) map[string]string {
+	data := make(map[string]string, len(l.memberKeys))
+	for _, key := range l.memberKeys {
+		if v := l.mm.GetLobbyMemberData(l.id, member, key); v != "" {
+			data[key] = v
+		}
+	}
+	return data
+}
+
+func (l *Lobby) onDataUpdate(cb LobbyDataUpdate) {
+	if !l.isLobby(cb.LobbySteamID) || cb.Success == 0 {
+		return
+	}
+	var events []LobbyEvent
+	if cb.MemberSteamID == l.id {
+		data := readLobbyData(l.mm, l.id)
+		l.mu.Lock()
+		keys := changedKeys(l.data, data)
+		l.data = data
+		l.mu.Unlock()
+		if len(keys) > 0 {
+			events = append(events, LobbyEvent{Type: LobbyDataChanged, Keys: keys})
+		}
+	} else {
+		data := l.readMemberData(cb.MemberSteamID)
+		l.mu.Lock()
+		keys := changedKeys(l.memberData[cb.MemberSteamID], data)
+		if slices.Contains(l.members, cb.MemberSteamID) {
+			l.memberData[cb.MemberSteamID] = data
+		}
+		l.mu.Unlock()
+		if len(keys) > 0 {
+			events = append(events, LobbyEvent{Type: LobbyMemberDataChanged, Member: cb.MemberSteamID, Keys: keys})
+		}
+	}
+	l.emit(append(events, l.checkOwner()...))
+}
+
+func (l *Lobby) onChatUpdate(cb LobbyChatUpdate) {
+	if !l.isLobby(cb.LobbySteamID) {
+		return
+	}
+	user, change := cb.UserChangedSteamID, cb.StateChange()
+	var events []LobbyEvent
+	l.mu.Lock()
+	switch {
+	case change.Has(EChatMemberStateChangeEntered):
+		if !slices.Contains(l.members, user) {
+			l.members = append(l.members, user)
+		}
+		events = append(events, LobbyEvent{Type: LobbyMemberJoined, Member: user, StateChange: change})
+	default:
+		l.members = slices.DeleteFunc(l.members, func(m CSteamID) bool { return m == user })
+		delete(l.memberData, user)
+		events = append(events, LobbyEvent{Type: LobbyMemberLeft, Member: user, StateChange: change})
+	}
+	l.mu.Unlock()
+
+	if change.Has(EChatMemberStateChangeEntered) {
+		data := l.readMemberData(user)
+		l.mu.Lock()
+		l.memberData[user] = data
+		l.mu.Unlock()
+	}
+	l.emit(append(events, l.checkOwner()...))
+}
+
+func (l *Lobby) onChatMsg(cb LobbyChatMsg) {
+	if !l.isLobby(cb.LobbySteamID) {
+		return
+	}
+	buf := make([]byte, lobbyChatEntryMax)
+	sender, entryType, n := l.mm.GetLobbyChatEntry(l.id, int(cb.ChatID), buf)
+	if n <= 0 {
+		return
+	}
+	l.emit([]LobbyEvent{{
+		Type:          LobbyChatReceived,
+		Member:        sender,
+		ChatEntryType: entryType,
+		ChatData:      buf[:min(n, len(buf))],
+	}})
+}
+
+// checkOwner re-reads the owner, which Steam changes without a dedicated
+// callback when the previous owner leaves or hands the lobby over.
+func (l *Lobby) checkOwner() []LobbyEvent {
+	owner := l.mm.GetLobbyOwner(l.id)
+	l.mu.Lock()
+	changed := owner != 0 && owner != l.owner
+	if changed {
+		l.owner = owner
+	}
+	l.mu.Unlock()
+	if !changed {
+		return nil
+	}
+	return []LobbyEvent{{Type: LobbyOwnerChanged, Member: owner}}
+}
+
+func (l *Lobby) emit(events []LobbyEvent) {
+	for _, e := range events {
+		l.events.emit(e)
+	}
+}
+
+func readLobbyData(mm ISteamMatchmaking, lobbyID CSteamID) map[string]string {
+	count := mm.GetLobbyDataCount(lobbyID)
+	data := make(map[string]string, count)
+	for i := 0; i < count; i++ {
+		if key, value, ok := mm.GetLobbyDataByIndex(lobbyID, i); ok {
+			data[key] = value
+		}
+	}
+	return data
+}
+
+// changedKeys returns the sorted keys whose values differ between before and
+// after, including keys present in only one of them.
+func changedKeys(before, after map[string]string) []string {
+	var keys []string
+	for k, v := range after {
+		if old, ok := before[k]; !ok || old != v {
+			keys = append(keys, k)
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
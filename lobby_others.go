@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+//go:build !windows
+
+package steamworks
+
+// lobbyCreatedPayload mirrors LobbyCreated_t under the 4-byte callback packing
+// Steam uses on Linux and macOS, which leaves the lobby ID unaligned.
+type lobbyCreatedPayload struct {
+	Result         EResult
+	SteamIDLobbyLo uint32
+	SteamIDLobbyHi uint32
+}
+
+func (p lobbyCreatedPayload) steamIDLobby() CSteamID {
+	return CSteamID(uint64(p.SteamIDLobbyHi)<<32 | uint64(p.SteamIDLobbyLo))
+}
+
+// lobbyEnterPayload mirrors LobbyEnter_t under 4-byte callback packing, which
+// drops the tail padding after the enter response.
+type lobbyEnterPayload struct {
+	SteamIDLobbyLo        uint32
+	SteamIDLobbyHi        uint32
+	ChatPermissions       uint32
+	Locked                bool
+	_                     [3]byte
+	ChatRoomEnterResponse EChatRoomEnterResponse
+}
+
+func (p lobbyEnterPayload) enter() LobbyEnter {
+	return LobbyEnter{
+		SteamIDLobby:          CSteamID(uint64(p.SteamIDLobbyHi)<<32 | uint64(p.SteamIDLobbyLo)),
+		ChatPermissions:       p.ChatPermissions,
+		Locked:                p.Locked,
+		ChatRoomEnterResponse: p.ChatRoomEnterResponse,
+	}
+}
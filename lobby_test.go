@@ -0,0 +1,391 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"maps"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+type fakeLobbyChatEntry struct {
+	sender    CSteamID
+	entryType EChatEntryType
+	data      []byte
+}
+
+// fakeMatchmaking holds the state of lobbies as Steam would report it.
+type fakeMatchmaking struct {
+	ISteamMatchmaking
+	mu         sync.Mutex
+	owner      CSteamID
+	members    []CSteamID
+	data       map[string]string
+	memberData map[CSteamID]map[string]string
+	chat       []fakeLobbyChatEntry
+	sent       [][]byte
+	left       bool
+}
+
+func newFakeMatchmaking(owner CSteamID, members ...CSteamID) *fakeMatchmaking {
+	return &fakeMatchmaking{
+		owner:      owner,
+		members:    members,
+		data:       map[string]string{},
+		memberData: map[CSteamID]map[string]string{},
+	}
+}
+
+func (f *fakeMatchmaking) GetLobbyOwner(CSteamID) CSteamID {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.owner
+}
+
+func (f *fakeMatchmaking) LobbyMembers(CSteamID) iter.Seq[CSteamID] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Values(slices.Clone(f.members))
+}
+
+func (f *fakeMatchmaking) GetLobbyDataCount(CSteamID) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.data)
+}
+
+func (f *fakeMatchmaking) GetLobbyDataByIndex(_ CSteamID, index int) (string, string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := slices.Sorted(maps.Keys(f.data))
+	if index >= len(keys) {
+		return "", "", false
+	}
+	return keys[index], f.data[keys[index]], true
+}
+
+func (f *fakeMatchmaking) GetLobbyData(_ CSteamID, key string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.data[key]
+}
+
+func (f *fakeMatchmaking) SetLobbyData(_ CSteamID, key, value string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data[key] = value
+	return true
+}
+
+func (f *fakeMatchmaking) DeleteLobbyData(_ CSteamID, key string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.data, key)
+	return true
+}
+
+func (f *fakeMatchmaking) GetLobbyMemberData(_ CSteamID, user CSteamID, key string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.memberData[user][key]
+}
+
+// SetLobbyMemberData sets the data of the first member, who stands in for the
+// local user.
+func (f *fakeMatchmaking) SetLobbyMemberData(_ CSteamID, key, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	self := f.members[0]
+	if f.memberData[self] == nil {
+		f.memberData[self] = map[string]string{}
+	}
+	f.memberData[self][key] = value
+}
+
+func (f *fakeMatchmaking) SendLobbyChatMsg(_ CSteamID, body []byte) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, slices.Clone(body))
+	return len(body) <= lobbyChatEntryMax
+}
+
+func (f *fakeMatchmaking) GetLobbyChatEntry(_ CSteamID, chatID int, data []byte) (CSteamID, EChatEntryType, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if chatID >= len(f.chat) {
+		return 0, EChatEntryTypeInvalid, 0
+	}
+	e := f.chat[chatID]
+	return e.sender, e.entryType, copy(data, e.data)
+}
+
+func (f *fakeMatchmaking) LeaveLobby(CSteamID) { f.left = true }
+
+func TestLobbyTracksMembersAndOwner(t *testing.T) {
+	const lobbyID CSteamID = 1000
+	mm := newFakeMatchmaking(1, 1, 2)
+	mm.memberData[2] = map[string]string{"ready": "0"}
+	d := NewCallbackDispatcher()
+	l := NewLobby(mm, d, lobbyID, "ready")
+	defer l.Close()
+
+	if got := l.Members(); !slices.Equal(got, []CSteamID{1, 2}) || l.Owner() != 1 {
+		t.Fatalf("Members=%v Owner=%d, want [1 2] owner 1", got, l.Owner())
+	}
+	if v, _ := l.MemberData(2, "ready"); v != "0" {
+		t.Fatalf("MemberData(2, ready)=%q, want 0", v)
+	}
+
+	var events []LobbyEvent
+	l.Subscribe(func(e LobbyEvent) { events = append(events, e) })
+
+	mm.members = append(mm.members, 3)
+	dispatchCallback(d, CallbackIDLobbyChatUpdate, LobbyChatUpdate{LobbySteamID: lobbyID, UserChangedSteamID: 3, ChatMemberStateChange: uint32(EChatMemberStateChangeEntered)})
+	mm.members, mm.owner = []CSteamID{2, 3}, 2
+	dispatchCallback(d, CallbackIDLobbyChatUpdate, LobbyChatUpdate{LobbySteamID: lobbyID, UserChangedSteamID: 1, ChatMemberStateChange: uint32(EChatMemberStateChangeDisconnected)})
+	dispatchCallback(d, CallbackIDLobbyChatUpdate, LobbyChatUpdate{LobbySteamID: lobbyID + 1, UserChangedSteamID: 9, ChatMemberStateChange: uint32(EChatMemberStateChangeEntered)})
+
+	want := []LobbyEvent{
+		{Type: LobbyMemberJoined, Member: 3, StateChange: EChatMemberStateChangeEntered},
+		{Type: LobbyMemberLeft, Member: 1, StateChange: EChatMemberStateChangeDisconnected},
+		{Type: LobbyOwnerChanged, Member: 2},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events=%+v, want %+v", events, want)
+	}
+	for i := range want {
+		if events[i].Type != want[i].Type || events[i].Member != want[i].Member || events[i].StateChange != want[i].StateChange {
+			t.Fatalf("event %d=%+v, want %+v", i, events[i], want[i])
+		}
+	}
+	if got := l.Members(); !slices.Equal(got, []CSteamID{2, 3}) || l.Owner() != 2 {
+		t.Fatalf("Members=%v Owner=%d, want [2 3] owner 2", got, l.Owner())
+	}
+}
+
+func TestLobbyTracksDataAndChat(t *testing.T) {
+	const lobbyID CSteamID = 1000
+	mm := newFakeMatchmaking(1, 1, 2)
+	mm.data["map"] = "dust"
+	mm.data["mode"] = "ctf"
+	d := NewCallbackDispatcher()
+	l := NewLobby(mm, d, lobbyID, "ready")
+	defer l.Close()
+
+	var events []LobbyEvent
+	l.Subscribe(func(e LobbyEvent) { events = append(events, e) })
+
+	if err := l.SetData("map", "nuke"); err != nil {
+		t.Fatalf("SetData error=%v", err)
+	}
+	delete(mm.data, "mode")
+	dispatchCallback(d, CallbackIDLobbyDataUpdate, LobbyDataUpdate{LobbySteamID: lobbyID, MemberSteamID: lobbyID, Success: 1})
+	mm.memberData[2] = map[string]string{"ready": "1"}
+	dispatchCallback(d, CallbackIDLobbyDataUpdate, LobbyDataUpdate{LobbySteamID: lobbyID, MemberSteamID: 2, Success: 1})
+	mm.chat = append(mm.chat, fakeLobbyChatEntry{sender: 2, entryType: EChatEntryTypeChatMsg, data: []byte("gl hf")})
+	dispatchCallback(d, CallbackIDLobbyChatMsg, LobbyChatMsg{LobbySteamID: lobbyID, UserSteamID: 2, ChatEntryType: uint8(EChatEntryTypeChatMsg), ChatID: 0})
+
+	if len(events) != 3 {
+		t.Fatalf("events=%+v, want data, member data and chat", events)
+	}
+	if e := events[0]; e.Type != LobbyDataChanged || !slices.Equal(e.Keys, []string{"map", "mode"}) {
+		t.Fatalf("data event=%+v, want keys [map mode]", e)
+	}
+	if e := events[1]; e.Type != LobbyMemberDataChanged || e.Member != 2 || !slices.Equal(e.Keys, []string{"ready"}) {
+		t.Fatalf("member data event=%+v", e)
+	}
+	if e := events[2]; e.Type != LobbyChatReceived || e.Member != 2 || string(e.ChatData) != "gl hf" {
+		t.Fatalf("chat event=%+v", e)
+	}
+	if got := l.Data(); !maps.Equal(got, map[string]string{"map": "nuke"}) {
+		t.Fatalf("Data=%v, want map=nuke", got)
+	}
+	if v, _ := l.MemberData(2, "ready"); v != "1" {
+		t.Fatalf("MemberData(2, ready)=%q, want 1", v)
+	}
+
+	l.Leave()
+	if !mm.left {
+		t.Fatalf("Leave did not call LeaveLobby")
+	}
+	dispatchCallback(d, CallbackIDLobbyDataUpdate, LobbyDataUpdate{LobbySteamID: lobbyID, MemberSteamID: lobbyID, Success: 1})
+	if len(events) != 3 {
+		t.Fatalf("event delivered after Leave: %+v", events[3:])
+	}
+}
+
+type fakeJoinMatchmaking struct {
+	ISteamMatchmaking
+	d     *CallbackDispatcher
+	enter LobbyEnter
+}
+
+func (f *fakeJoinMatchmaking) JoinLobby(lobbyID CSteamID) SteamAPICall_t {
+	go func() {
+		// Another lobby's enter must not complete the join.
+		dispatchCallback(f.d, CallbackIDLobbyEnter, LobbyEnter{SteamIDLobby: lobbyID + 1, ChatRoomEnterResponse: EChatRoomEnterResponseSuccess})
+		dispatchCallback(f.d, CallbackIDLobbyEnter, f.enter)
+	}()
+	return 1
+}
+
+func TestJoinLobbyAsync(t *testing.T) {
+	tests := []struct {
+		response EChatRoomEnterResponse
+		want     error
+	}{
+		{EChatRoomEnterResponseSuccess, nil},
+		{EChatRoomEnterResponseFull, ErrLobbyFull},
+		{EChatRoomEnterResponseDoesntExist, ErrLobbyNotFound},
+		{EChatRoomEnterResponseBanned, ErrLobbyBanned},
+		{EChatRoomEnterResponseLimited, ErrLobbyLimitedAccount},
+		{EChatRoomEnterResponseYouBlockedMember, ErrLobbyNotAllowed},
+		{EChatRoomEnterResponseError, ErrLobbyJoinFailed},
+	}
+	for _, tt := range tests {
+		d := NewCallbackDispatcher()
+		mm := &fakeJoinMatchmaking{d: d, enter: LobbyEnter{SteamIDLobby: 42, Locked: true, ChatRoomEnterResponse: tt.response}}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		got, err := joinLobbyAsync(ctx, mm, d, 42, pendingCall)
+		cancel()
+		if !errors.Is(err, tt.want) || (tt.want == nil) != (err == nil) {
+			t.Fatalf("response %d: error=%v, want %v", tt.response, err, tt.want)
+		}
+		if err != nil && !errors.Is(err, ErrLobbyJoinFailed) {
+			t.Fatalf("response %d: error %v does not wrap %v", tt.response, err, ErrLobbyJoinFailed)
+		}
+		if got.LobbyID != 42 || !got.Locked || got.Response != tt.response {
+			t.Fatalf("response %d: result=%+v", tt.response, got)
+		}
+	}
+}
+
+func pendingCall(SteamAPICall_t) (failed, ok bool) { return false, false }
+
+// fakeFailedJoinMatchmaking starts a join whose call result fails without a
+// LobbyEnter callback, as when Steam cannot reach the lobby.
+type fakeFailedJoinMatchmaking struct {
+	ISteamMatchmaking
+}
+
+func (fakeFailedJoinMatchmaking) JoinLobby(CSteamID) SteamAPICall_t { return 7 }
+
+func TestJoinLobbyAsyncCallFailed(t *testing.T) {
+	d := NewCallbackDispatcher()
+	var polled SteamAPICall_t
+	failedCall := func(call SteamAPICall_t) (bool, bool) {
+		polled = call
+		return true, true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := joinLobbyAsync(ctx, fakeFailedJoinMatchmaking{}, d, 42, failedCall)
+	if !errors.Is(err, ErrLobbyJoinFailed) || ctx.Err() != nil {
+		t.Fatalf("error=%v, want %v before the deadline", err, ErrLobbyJoinFailed)
+	}
+	if polled != 7 {
+		t.Fatalf("polled call %d, want 7", polled)
+	}
+}
+
+// fakeLobbyJoinMatchmaking joins a fakeMatchmaking lobby: it posts LobbyEnter
+// and, before JoinLobby can return, a LobbyChatUpdate for another member.
+type fakeLobbyJoinMatchmaking struct {
+	*fakeMatchmaking
+	d        *CallbackDispatcher
+	response EChatRoomEnterResponse
+}
+
+func (f *fakeLobbyJoinMatchmaking) JoinLobby(lobbyID CSteamID) SteamAPICall_t {
+	response := f.response
+	go func() {
+		dispatchCallback(f.d, CallbackIDLobbyEnter, LobbyEnter{SteamIDLobby: lobbyID, ChatRoomEnterResponse: response})
+		if response != EChatRoomEnterResponseSuccess {
+			return
+		}
+		f.mu.Lock()
+		f.members = append(f.members, 3)
+		f.mu.Unlock()
+		dispatchCallback(f.d, CallbackIDLobbyChatUpdate, LobbyChatUpdate{
+			LobbySteamID:          lobbyID,
+			UserChangedSteamID:    3,
+			ChatMemberStateChange: uint32(EChatMemberStateChangeEntered),
+		})
+	}()
+	return 1
+}
+
+func TestJoinLobby(t *testing.T) {
+	d := NewCallbackDispatcher()
+	mm := &fakeLobbyJoinMatchmaking{fakeMatchmaking: newFakeMatchmaking(2, 1, 2), d: d, response: EChatRoomEnterResponseSuccess}
+	mm.data["map"] = "dust"
+	mm.memberData[2] = map[string]string{"ready": "1"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	l, err := JoinLobby(ctx, mm, d, 42, "ready")
+	if err != nil {
+		t.Fatalf("JoinLobby error=%v", err)
+	}
+	defer l.Close()
+	if l.ID() != 42 || l.Owner() != 2 {
+		t.Fatalf("ID=%d Owner=%d, want 42 and 2", l.ID(), l.Owner())
+	}
+	if v, _ := l.Get("map"); v != "dust" {
+		t.Fatalf("Get(map)=%q, want dust", v)
+	}
+	if v, _ := l.MemberData(2, "ready"); v != "1" {
+		t.Fatalf("MemberData(2, ready)=%q, want 1", v)
+	}
+	deadline := time.Now().Add(time.Second)
+	for !l.IsMember(3) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !slices.Equal(l.Members(), []CSteamID{1, 2, 3}) {
+		t.Fatalf("Members=%v, want [1 2 3]", l.Members())
+	}
+
+	mm.response = EChatRoomEnterResponseFull
+	if l, err := JoinLobby(ctx, mm, d, 43); l != nil || !errors.Is(err, ErrLobbyFull) {
+		t.Fatalf("JoinLobby(full)=%v, %v, want nil, %v", l, err, ErrLobbyFull)
+	}
+}
+
+func TestLobbyCallResultPayloadLayout(t *testing.T) {
+	createdSize, enterSize := uintptr(12), uintptr(20)
+	if runtime.GOOS == "windows" {
+		createdSize, enterSize = 16, 24
+	}
+	var created lobbyCreatedPayload
+	if got := unsafe.Sizeof(created); got != createdSize {
+		t.Fatalf("lobbyCreatedPayload size=%d, want %d", got, createdSize)
+	}
+	var enter lobbyEnterPayload
+	if got := unsafe.Sizeof(enter); got != enterSize {
+		t.Fatalf("lobbyEnterPayload size=%d, want %d", got, enterSize)
+	}
+
+	const lobbyID CSteamID = 0x0186000000000001
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(&created)), unsafe.Sizeof(created))
+	putUint64(buf[createdSize-8:], uint64(lobbyID))
+	if got := created.steamIDLobby(); got != lobbyID {
+		t.Fatalf("LobbyCreated lobby=%#x, want %#x", got, lobbyID)
+	}
+	buf = unsafe.Slice((*byte)(unsafe.Pointer(&enter)), unsafe.Sizeof(enter))
+	putUint64(buf, uint64(lobbyID))
+	if got := enter.enter().SteamIDLobby; got != lobbyID {
+		t.Fatalf("LobbyEnter lobby=%#x, want %#x", got, lobbyID)
+	}
+	if got, want := unsafe.Offsetof(LobbyEnter{}.ChatRoomEnterResponse), uintptr(16); got != want {
+		t.Fatalf("LobbyEnter.ChatRoomEnterResponse offset=%d, want %d", got, want)
+	}
+}
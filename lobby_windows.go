@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+// lobbyCreatedPayload mirrors LobbyCreated_t under Windows' 8-byte callback packing.
+type lobbyCreatedPayload struct {
+	Result       EResult
+	_            uint32
+	SteamIDLobby CSteamID
+}
+
+func (p lobbyCreatedPayload) steamIDLobby() CSteamID {
+	return p.SteamIDLobby
+}
+
+// lobbyEnterPayload mirrors LobbyEnter_t under Windows' 8-byte callback packing.
+type lobbyEnterPayload LobbyEnter
+
+func (p lobbyEnterPayload) enter() LobbyEnter {
+	return LobbyEnter(p)
+}
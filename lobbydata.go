@@ -0,0 +1,313 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Lobby data limits enforced by Steam. The key length is in bytes; the value
+// length is a buffer size in bytes, including the NUL terminator.
+const (
+	LobbyDataMaxKeyLength   = 255
+	LobbyDataMaxValueLength = 8192
+)
+
+var (
+	ErrLobbyDataKeyInvalid   = errors.New("steamworks: invalid lobby data key")
+	ErrLobbyDataValueTooLong = errors.New("steamworks: lobby data value too long")
+	ErrLobbyDataUnsupported  = errors.New("steamworks: unsupported lobby data type")
+	ErrLobbyDataMalformed    = errors.New("steamworks: malformed lobby data value")
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// lobbyField is a struct field tagged with `steam:"key[,omitempty]"`.
+type lobbyField struct {
+	key       string
+	index     int
+	omitEmpty bool
+}
+
+// lobbyEntry is an encoded lobbyField. Omitted entries are zero values of
+// omitempty fields and are deleted rather than set.
+type lobbyEntry struct {
+	key     string
+	value   string
+	numeric bool
+	omitted bool
+}
+
+var lobbyFieldCache sync.Map // map[reflect.Type][]lobbyField
+
+// MarshalLobbyData writes the tagged fields of v, a struct or a pointer to
+// one, as lobby data of lobbyID. Only values that differ from the current
+// lobby data are set, and keys of omitempty fields holding their zero value
+// are deleted. Only the lobby owner can set lobby data.
+//
+// Fields are mapped with `steam:"key"` tags; `steam:"key,omitempty"` deletes
+// the key instead of writing a zero value. Strings, bools ("1" and "0"),
+// integers including named enum types, floats, and time.Time (Unix seconds)
+// are supported, so integer, bool and time keys work with numerical lobby
+// list filters.
+func MarshalLobbyData(mm ISteamMatchmaking, lobbyID CSteamID, v any) error {
+	entries, err := encodeLobbyData(v)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		current := mm.GetLobbyData(lobbyID, e.key)
+		switch {
+		case e.omitted && current != "":
+			if !mm.DeleteLobbyData(lobbyID, e.key) {
+				return fmt.Errorf("%w: deleting %q", ErrLobbyDataRejected, e.key)
+			}
+		case !e.omitted && current != e.value:
+			if !mm.SetLobbyData(lobbyID, e.key, e.value) {
+				return fmt.Errorf("%w: setting %q", ErrLobbyDataRejected, e.key)
+			}
+		}
+	}
+	return nil
+}
+
+// UnmarshalLobbyData reads the lobby data of lobbyID into the tagged fields of
+// the struct v points to. Fields whose key is unset are reset to their zero
+// value.
+func UnmarshalLobbyData(mm ISteamMatchmaking, lobbyID CSteamID, v any) error {
+	return decodeLobbyData(v, func(key string) string { return mm.GetLobbyData(lobbyID, key) })
+}
+
+// MarshalLobbyMemberData writes the tagged fields of v as the member data of
+// self, the local user, in lobbyID. Like MarshalLobbyData, only values that
+// differ from self's current member data are set. Steam cannot delete member
+// data, so keys of omitempty fields holding their zero value are set to "".
+func MarshalLobbyMemberData(mm ISteamMatchmaking, lobbyID, self CSteamID, v any) error {
+	entries, err := encodeLobbyData(v)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		value := e.value
+		if e.omitted {
+			value = ""
+		}
+		if mm.GetLobbyMemberData(lobbyID, self, e.key) != value {
+			mm.SetLobbyMemberData(lobbyID, e.key, value)
+		}
+	}
+	return nil
+}
+
+// UnmarshalLobbyMemberData reads member's data in lobbyID into the tagged
+// fields of the struct v points to.
+func UnmarshalLobbyMemberData(mm ISteamMatchmaking, lobbyID, member CSteamID, v any) error {
+	return decodeLobbyData(v, func(key string) string { return mm.GetLobbyMemberData(lobbyID, member, key) })
+}
+
+// UnmarshalLobbyDataMap decodes lobby data already read into a map, such as
+// LobbySummary.Data or Lobby.Data, into the struct v points to.
+func UnmarshalLobbyDataMap(data map[string]string, v any) error {
+	return decodeLobbyData(v, func(key string) string { return data[key] })
+}
+
+// LobbyDataKeys returns the keys of v's tagged fields, for use with
+// LobbyQuery.Keys and NewLobby.
+func LobbyDataKeys(v any) ([]string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T is not a struct", ErrLobbyDataUnsupported, v)
+	}
+	fields, err := lobbyFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = f.key
+	}
+	return keys, nil
+}
+
+func encodeLobbyData(v any) ([]lobbyEntry, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T is not a struct", ErrLobbyDataUnsupported, v)
+	}
+	fields, err := lobbyFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]lobbyEntry, len(fields))
+	for i, f := range fields {
+		fv := rv.Field(f.index)
+		value, numeric := encodeLobbyValue(fv)
+		if len(value) >= LobbyDataMaxValueLength {
+			return nil, fmt.Errorf("%w: %q is %d bytes", ErrLobbyDataValueTooLong, f.key, len(value))
+		}
+		entries[i] = lobbyEntry{key: f.key, value: value, numeric: numeric, omitted: f.omitEmpty && fv.IsZero()}
+	}
+	return entries, nil
+}
+
+func decodeLobbyData(v any, get func(key string) string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T is not a pointer to a struct", ErrLobbyDataUnsupported, v)
+	}
+	rv = rv.Elem()
+	fields, err := lobbyFields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if err := decodeLobbyValue(rv.Field(f.index), get(f.key)); err != nil {
+			return fmt.Errorf("%w: %q: %v", ErrLobbyDataMalformed, f.key, err)
+		}
+	}
+	return nil
+}
+
+func lobbyFields(t reflect.Type) ([]lobbyField, error) {
+	if cached, ok := lobbyFieldCache.Load(t); ok {
+		return cached.([]lobbyField), nil
+	}
+	var fields []lobbyField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("steam")
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		key, opts, _ := strings.Cut(tag, ",")
+		if key == "" || len(key) > LobbyDataMaxKeyLength {
+			return nil, fmt.Errorf("%w: %s.%s has key %q", ErrLobbyDataKeyInvalid, t.Name(), sf.Name, key)
+		}
+		if !lobbyTypeSupported(sf.Type) {
+			return nil, fmt.Errorf("%w: %s.%s is %s", ErrLobbyDataUnsupported, t.Name(), sf.Name, sf.Type)
+		}
+		fields = append(fields, lobbyField{key: key, index: i, omitEmpty: opts == "omitempty"})
+	}
+	lobbyFieldCache.Store(t, fields)
+	return fields, nil
+}
+
+func lobbyTypeSupported(t reflect.Type) bool {
+	if t == timeType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// encodeLobbyValue formats v and reports whether it is numeric, that is usable
+// with numerical lobby list filters.
+func encodeLobbyValue(v reflect.Value) (string, bool) {
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", true
+		}
+		return strconv.FormatInt(t.Unix(), 10), true
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return "1", true
+		}
+		return "0", true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), false
+	default:
+		return v.String(), false
+	}
+}
+
+func decodeLobbyValue(v reflect.Value, s string) error {
+	if s == "" {
+		v.SetZero()
+		return nil
+	}
+	if v.Type() == timeType {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(time.Unix(sec, 0)))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		v.SetString(s)
+	}
+	return nil
+}
+
+// lobbyFilters turns the tagged fields of v into lobby list filters: numeric
+// fields become numerical filters and the others string filters. Omitted
+// fields and zero times are skipped.
+func lobbyFilters(v any, comparison ELobbyComparison) ([]func(ISteamMatchmaking), error) {
+	entries, err := encodeLobbyData(v)
+	if err != nil {
+		return nil, err
+	}
+	var filters []func(ISteamMatchmaking)
+	for _, e := range entries {
+		if e.omitted || e.value == "" {
+			continue
+		}
+		key, value := e.key, e.value
+		if !e.numeric {
+			filters = append(filters, func(mm ISteamMatchmaking) { mm.AddRequestLobbyListStringFilter(key, value, comparison) })
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < math.MinInt32 || n > math.MaxInt32 {
+			return nil, fmt.Errorf("%w: %q value %s does not fit a numerical filter", ErrLobbyDataUnsupported, key, value)
+		}
+		filters = append(filters, func(mm ISteamMatchmaking) { mm.AddRequestLobbyListNumericalFilter(key, int(n), comparison) })
+	}
+	return filters, nil
+}
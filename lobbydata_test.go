@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testGameMode int
+
+type testLobbyMeta struct {
+	Map      string       `steam:"map"`
+	Mode     testGameMode `steam:"mode"`
+	MMR      uint16       `steam:"mmr"`
+	Ranked   bool         `steam:"ranked"`
+	Started  time.Time    `steam:"started,omitempty"`
+	Password string       `steam:"pw,omitempty"`
+	Local    string
+	Skipped  string `steam:"-"`
+}
+
+type countingMatchmaking struct {
+	*fakeMatchmaking
+	sets []string
+}
+
+func (f *countingMatchmaking) SetLobbyData(lobbyID CSteamID, key, value string) bool {
+	f.sets = append(f.sets, key)
+	return f.fakeMatchmaking.SetLobbyData(lobbyID, key, value)
+}
+
+func TestMarshalLobbyDataDiffsAndDeletes(t *testing.T) {
+	mm := &countingMatchmaking{fakeMatchmaking: newFakeMatchmaking(1)}
+	mm.data["map"] = "dust"
+	mm.data["pw"] = "hunter2"
+	mm.data["other"] = "kept"
+
+	started := time.Unix(1700000000, 0)
+	meta := testLobbyMeta{Map: "dust", Mode: 3, MMR: 1500, Ranked: true, Started: started, Local: "x", Skipped: "y"}
+	if err := MarshalLobbyData(mm, 1000, &meta); err != nil {
+		t.Fatalf("MarshalLobbyData error=%v", err)
+	}
+	want := map[string]string{"map": "dust", "mode": "3", "mmr": "1500", "ranked": "1", "started": "1700000000", "other": "kept"}
+	if !maps.Equal(mm.data, want) {
+		t.Fatalf("data=%v, want %v", mm.data, want)
+	}
+	if !slices.Equal(mm.sets, []string{"mode", "mmr", "ranked", "started"}) {
+		t.Fatalf("set keys=%q, want unchanged map skipped", mm.sets)
+	}
+
+	var got testLobbyMeta
+	got.Password = "stale"
+	if err := UnmarshalLobbyData(mm, 1000, &got); err != nil {
+		t.Fatalf("UnmarshalLobbyData error=%v", err)
+	}
+	if got.Map != "dust" || got.Mode != 3 || got.MMR != 1500 || !got.Ranked || !got.Started.Equal(started) || got.Password != "" {
+		t.Fatalf("decoded=%+v", got)
+	}
+
+	mm.sets = nil
+	meta.Started = time.Time{}
+	if err := MarshalLobbyData(mm, 1000, meta); err != nil {
+		t.Fatalf("MarshalLobbyData error=%v", err)
+	}
+	if _, ok := mm.data["started"]; ok || len(mm.sets) != 0 {
+		t.Fatalf("data=%v sets=%q, want started deleted and nothing set", mm.data, mm.sets)
+	}
+}
+
+func (f *countingMatchmaking) SetLobbyMemberData(lobbyID CSteamID, key, value string) {
+	f.sets = append(f.sets, key)
+	f.fakeMatchmaking.SetLobbyMemberData(lobbyID, key, value)
+}
+
+func TestMarshalLobbyMemberDataDiffs(t *testing.T) {
+	type memberMeta struct {
+		Team  int    `steam:"team"`
+		Ready bool   `steam:"ready"`
+		Note  string `steam:"note,omitempty"`
+		Score int    `steam:"score,omitempty"`
+		Muted bool   `steam:"muted,omitempty"`
+	}
+	mm := &countingMatchmaking{fakeMatchmaking: newFakeMatchmaking(1, 2, 1)}
+	mm.memberData[2] = map[string]string{"team": "1", "note": "afk", "score": "7"}
+	mm.memberData[1] = map[string]string{"ready": "1"}
+
+	if err := MarshalLobbyMemberData(mm, 1000, 2, memberMeta{Team: 1, Ready: true}); err != nil {
+		t.Fatalf("MarshalLobbyMemberData error=%v", err)
+	}
+	want := map[string]string{"team": "1", "ready": "1", "note": "", "score": ""}
+	if !maps.Equal(mm.memberData[2], want) {
+		t.Fatalf("member data=%v, want %v", mm.memberData[2], want)
+	}
+	if !slices.Equal(mm.sets, []string{"ready", "note", "score"}) {
+		t.Fatalf("set keys=%q, want unchanged team and unset muted skipped", mm.sets)
+	}
+
+	mm.sets = nil
+	if err := MarshalLobbyMemberData(mm, 1000, 2, memberMeta{Team: 1, Ready: true}); err != nil {
+		t.Fatalf("MarshalLobbyMemberData error=%v", err)
+	}
+	if len(mm.sets) != 0 {
+		t.Fatalf("set keys=%q, want nothing set", mm.sets)
+	}
+}
+
+func TestLobbyDataValidation(t *testing.T) {
+	mm := newFakeMatchmaking(1)
+	long := testLobbyMeta{Map: strings.Repeat("m", LobbyDataMaxValueLength)}
+	if err := MarshalLobbyData(mm, 1, long); !errors.Is(err, ErrLobbyDataValueTooLong) {
+		t.Fatalf("long value error=%v, want %v", err, ErrLobbyDataValueTooLong)
+	}
+	if len(mm.data) != 0 {
+		t.Fatalf("data=%v, want nothing written for invalid input", mm.data)
+	}
+
+	type badKey struct {
+		V string `steam:",omitempty"`
+	}
+	if err := MarshalLobbyData(mm, 1, badKey{}); !errors.Is(err, ErrLobbyDataKeyInvalid) {
+		t.Fatalf("empty key error=%v, want %v", err, ErrLobbyDataKeyInvalid)
+	}
+	type badType struct {
+		V []string `steam:"v"`
+	}
+	if err := MarshalLobbyData(mm, 1, badType{}); !errors.Is(err, ErrLobbyDataUnsupported) {
+		t.Fatalf("slice field error=%v, want %v", err, ErrLobbyDataUnsupported)
+	}
+	if err := UnmarshalLobbyData(mm, 1, testLobbyMeta{}); !errors.Is(err, ErrLobbyDataUnsupported) {
+		t.Fatalf("non-pointer error=%v, want %v", err, ErrLobbyDataUnsupported)
+	}
+
+	var meta testLobbyMeta
+	if err := UnmarshalLobbyDataMap(map[string]string{"mmr": "70000"}, &meta); !errors.Is(err, ErrLobbyDataMalformed) {
+		t.Fatalf("overflowing mmr error=%v, want %v", err, ErrLobbyDataMalformed)
+	}
+}
+
+func TestLobbyQueryFilterData(t *testing.T) {
+	mm := &fakeLobbyList{}
+	q := NewLobbyQuery(mm, nil).FilterData(testLobbyMeta{Map: "dust", Mode: 2, Ranked: true}, ELobbyComparisonEqual)
+	q.apply()
+	want := []string{
+		"string map dust 0",
+		"numeric mode 2 0",
+		"numeric mmr 0 0",
+		"numeric ranked 1 0",
+	}
+	if !slices.Equal(mm.calls, want) {
+		t.Fatalf("calls=%q, want %q", mm.calls, want)
+	}
+
+	keys, err := LobbyDataKeys(testLobbyMeta{})
+	if err != nil || !slices.Equal(keys, []string{"map", "mode", "mmr", "ranked", "started", "pw"}) {
+		t.Fatalf("LobbyDataKeys=%q, %v", keys, err)
+	}
+}
@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Lobby message frames start with a NUL byte so they never read as chat text,
+// followed by "LM" and the frame format version. The rest of the 16-byte
+// header holds the message kind and version, a per-sender message number, and
+// the chunk index and count, all little-endian.
+const (
+	lobbyFrameHeaderSize = 16
+	lobbyFrameFormat     = 1
+	lobbyFramePayloadMax = lobbyChatEntryMax - lobbyFrameHeaderSize
+	lobbyMessageMaxParts = 256
+)
+
+// LobbyMessageMaxSize is the largest payload a LobbyMessenger sends.
+const LobbyMessageMaxSize = lobbyMessageMaxParts * lobbyFramePayloadMax
+
+var lobbyFrameMagic = [4]byte{0, 'L', 'M', lobbyFrameFormat}
+
+var ErrLobbyMessageTooLarge = errors.New("steamworks: lobby message too large")
+
+// LobbyMessage is a typed message exchanged through a LobbyMessenger.
+type LobbyMessage struct {
+	Sender CSteamID
+	// Kind and Version are chosen by the application to tell messages and
+	// revisions of their payload apart.
+	Kind    uint16
+	Version uint16
+	Payload []byte
+}
+
+// LobbyChatText is a lobby chat entry that is not a LobbyMessage, such as
+// text typed by a player.
+type LobbyChatText struct {
+	Sender    CSteamID
+	EntryType EChatEntryType
+	Text      string
+}
+
+// LobbyMessenger sends and receives LobbyMessage values over lobby chat.
+// Payloads larger than one chat entry are split into chunks and reassembled
+// on arrival. Steam delivers lobby chat in order and echoes it to the sender,
+// so a messenger also receives its own messages.
+type LobbyMessenger struct {
+	mm ISteamMatchmaking
+	id CSteamID
+
+	// sendMu keeps the chunks of one message together in the chat and
+	// guards next.
+	sendMu sync.Mutex
+	next   uint32
+
+	mu      sync.Mutex
+	pending map[CSteamID]*lobbyMessageParts
+
+	messages eventSource[LobbyMessage]
+	chat     eventSource[LobbyChatText]
+	removes  []func()
+}
+
+// lobbyMessageParts collects the chunks of one message from one sender.
+type lobbyMessageParts struct {
+	number  uint32
+	kind    uint16
+	version uint16
+	count   int
+	payload []byte
+	next    int
+}
+
+// NewLobbyMessenger exchanges messages in lobbyID and subscribes to its
+// LobbyChatMsg_t callbacks on d. d may be nil, in which case the messenger can
+// only send.
+func NewLobbyMessenger(mm ISteamMatchmaking, d *CallbackDispatcher, lobbyID CSteamID) *LobbyMessenger {
+	m := &LobbyMessenger{mm: mm, id: lobbyID, pending: make(map[CSteamID]*lobbyMessageParts)}
+	if d != nil {
+		m.removes = append(m.removes, AddCallback(d, CallbackIDLobbyChatMsg, m.onChatMsg))
+	}
+	return m
+}
+
+// Send broadcasts payload to every lobby member as a message of the given
+// kind and version.
+func (m *LobbyMessenger) Send(kind, version uint16, payload []byte) error {
+	if len(payload) > LobbyMessageMaxSize {
+		return fmt.Errorf("%w: %d bytes", ErrLobbyMessageTooLarge, len(payload))
+	}
+	count := max(1, (len(payload)+lobbyFramePayloadMax-1)/lobbyFramePayloadMax)
+	m.sendMu.Lock()
+	defer m.sendMu.Unlock()
+	number := m.next
+	m.next++
+
+	frame := make([]byte, 0, lobbyChatEntryMax)
+	for i := 0; i < count; i++ {
+		chunk := payload[i*lobbyFramePayloadMax : min(len(payload), (i+1)*lobbyFramePayloadMax)]
+		frame = append(frame[:0], lobbyFrameMagic[:]...)
+		frame = binary.LittleEndian.AppendUint16(frame, kind)
+		frame = binary.LittleEndian.AppendUint16(frame, version)
+		frame = binary.LittleEndian.AppendUint32(frame, number)
+		frame = binary.LittleEndian.AppendUint16(frame, uint16(i))
+		frame = binary.LittleEndian.AppendUint16(frame, uint16(count))
+		frame = append(frame, chunk...)
+		if !m.mm.SendLobbyChatMsg(m.id, frame) {
+			return fmt.Errorf("%w: chunk %d of %d", ErrLobbyChatRejected, i+1, count)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers fn for complete messages. Calling the returned function
+// unsubscribes it.
+func (m *LobbyMessenger) Subscribe(fn func(LobbyMessage)) (unsubscribe func()) {
+	return m.messages.subscribe(fn)
+}
+
+// SubscribeChat registers fn for chat entries that are not messages. Without
+// a chat subscriber they are ignored. Calling the returned function
+// unsubscribes it.
+func (m *LobbyMessenger) SubscribeChat(fn func(LobbyChatText)) (unsubscribe func()) {
+	return m.chat.subscribe(fn)
+}
+
+// Close unsubscribes the messenger from its dispatcher and drops partially
+// received messages.
+func (m *LobbyMessenger) Close() {
+	for _, remove := range m.removes {
+		remove()
+	}
+	m.removes = nil
+	m.mu.Lock()
+	clear(m.pending)
+	m.mu.Unlock()
+}
+
+func (m *LobbyMessenger) onChatMsg(cb LobbyChatMsg) {
+	if cb.LobbySteamID != m.id {
+		return
+	}
+	buf := make([]byte, lobbyChatEntryMax)
+	sender, entryType, n := m.mm.GetLobbyChatEntry(m.id, int(cb.ChatID), buf)
+	if n <= 0 {
+		return
+	}
+	data := buf[:min(n, len(buf))]
+	if entryType != EChatEntryTypeChatMsg || len(data) < lobbyFrameHeaderSize || [4]byte(data) != lobbyFrameMagic {
+		m.chat.emit(LobbyChatText{Sender: sender, EntryType: entryType, Text: cStringToGo(data)})
+		return
+	}
+	if msg, ok := m.reassemble(sender, data); ok {
+		m.messages.emit(msg)
+	}
+}
+
+// reassemble adds a frame from sender and returns the message it completes.
+// Frames arrive in order, so each sender has at most one message in progress;
+// a frame that does not continue it drops the partial message.
+func (m *LobbyMessenger) reassemble(sender CSteamID, frame []byte) (LobbyMessage, bool) {
+	kind := binary.LittleEndian.Uint16(frame[4:])
+	version := binary.LittleEndian.Uint16(frame[6:])
+	number := binary.LittleEndian.Uint32(frame[8:])
+	index := int(binary.LittleEndian.Uint16(frame[12:]))
+	count := int(binary.LittleEndian.Uint16(frame[14:]))
+	chunk := frame[lobbyFrameHeaderSize:]
+	if count == 0 || count > lobbyMessageMaxParts || index >= count {
+		return LobbyMessage{}, false
+	}
+	if count == 1 {
+		m.mu.Lock()
+		delete(m.pending, sender)
+		m.mu.Unlock()
+		return LobbyMessage{Sender: sender, Kind: kind, Version: version, Payload: chunk}, true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	parts := m.pending[sender]
+	if index == 0 {
+		parts = &lobbyMessageParts{number: number, kind: kind, version: version, count: count}
+		m.pending[sender] = parts
+	} else if parts == nil || parts.number != number || parts.count != count || parts.next != index {
+		delete(m.pending, sender)
+		return LobbyMessage{}, false
+	}
+	parts.payload = append(parts.payload, chunk...)
+	parts.next++
+	if parts.next < parts.count {
+		return LobbyMessage{}, false
+	}
+	delete(m.pending, sender)
+	return LobbyMessage{Sender: sender, Kind: parts.kind, Version: parts.version, Payload: parts.payload}, true
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// deliverLobbyChat moves the frames sent through mm into its chat log as
+// entries from sender and dispatches LobbyChatMsg_t for each of them.
+func deliverLobbyChat(d *CallbackDispatcher, mm *fakeMatchmaking, lobbyID, sender CSteamID) {
+	mm.mu.Lock()
+	sent := mm.sent
+	mm.sent = nil
+	mm.mu.Unlock()
+	for _, body := range sent {
+		postLobbyChat(d, mm, lobbyID, sender, EChatEntryTypeChatMsg, body)
+	}
+}
+
+func postLobbyChat(d *CallbackDispatcher, mm *fakeMatchmaking, lobbyID, sender CSteamID, entryType EChatEntryType, body []byte) {
+	mm.mu.Lock()
+	chatID := len(mm.chat)
+	mm.chat = append(mm.chat, fakeLobbyChatEntry{sender: sender, entryType: entryType, data: body})
+	mm.mu.Unlock()
+	dispatchCallback(d, CallbackIDLobbyChatMsg, LobbyChatMsg{LobbySteamID: lobbyID, UserSteamID: sender, ChatEntryType: uint8(entryType), ChatID: int32(chatID)})
+}
+
+func TestLobbyMessengerChunksAndReassembles(t *testing.T) {
+	const lobbyID CSteamID = 1000
+	mm := newFakeMatchmaking(1, 1, 2)
+	d := NewCallbackDispatcher()
+	m := NewLobbyMessenger(mm, d, lobbyID)
+	defer m.Close()
+
+	var got []LobbyMessage
+	var chat []LobbyChatText
+	m.Subscribe(func(msg LobbyMessage) { got = append(got, msg) })
+	m.SubscribeChat(func(c LobbyChatText) { chat = append(chat, c) })
+
+	big := bytes.Repeat([]byte("0123456789"), 1000)
+	if err := m.Send(7, 2, big); err != nil {
+		t.Fatalf("Send error=%v", err)
+	}
+	if len(mm.sent) != 3 {
+		t.Fatalf("sent %d frames, want 3 for %d bytes", len(mm.sent), len(big))
+	}
+	for _, frame := range mm.sent {
+		if len(frame) > lobbyChatEntryMax {
+			t.Fatalf("frame of %d bytes exceeds %d", len(frame), lobbyChatEntryMax)
+		}
+	}
+	frames := mm.sent
+	mm.sent = nil
+	// Human chat between the chunks of a message is reported separately.
+	postLobbyChat(d, mm, lobbyID, 2, EChatEntryTypeChatMsg, frames[0])
+	postLobbyChat(d, mm, lobbyID, 3, EChatEntryTypeChatMsg, []byte("ready?\x00"))
+	postLobbyChat(d, mm, lobbyID, 2, EChatEntryTypeChatMsg, frames[1])
+	postLobbyChat(d, mm, lobbyID+1, 2, EChatEntryTypeChatMsg, frames[2])
+	if len(got) != 0 {
+		t.Fatalf("message delivered before its last chunk: %+v", got)
+	}
+	postLobbyChat(d, mm, lobbyID, 2, EChatEntryTypeChatMsg, frames[2])
+
+	if err := m.Send(8, 1, []byte("go")); err != nil {
+		t.Fatalf("Send error=%v", err)
+	}
+	deliverLobbyChat(d, mm, lobbyID, 1)
+
+	if len(got) != 2 {
+		t.Fatalf("messages=%d, want 2", len(got))
+	}
+	if g := got[0]; g.Sender != 2 || g.Kind != 7 || g.Version != 2 || !bytes.Equal(g.Payload, big) {
+		t.Fatalf("first message sender=%d kind=%d version=%d len=%d", g.Sender, g.Kind, g.Version, len(g.Payload))
+	}
+	if g := got[1]; g.Sender != 1 || g.Kind != 8 || g.Version != 1 || string(g.Payload) != "go" {
+		t.Fatalf("second message=%+v", g)
+	}
+	if len(chat) != 1 || chat[0].Sender != 3 || chat[0].Text != "ready?" {
+		t.Fatalf("chat=%+v, want one text entry from 3", chat)
+	}
+}
+
+// yieldingMatchmaking yields after every chat message, so concurrent senders
+// interleave unless Send keeps a message's chunks together.
+type yieldingMatchmaking struct {
+	*fakeMatchmaking
+}
+
+func (f yieldingMatchmaking) SendLobbyChatMsg(lobbyID CSteamID, body []byte) bool {
+	ok := f.fakeMatchmaking.SendLobbyChatMsg(lobbyID, body)
+	runtime.Gosched()
+	return ok
+}
+
+func TestLobbyMessengerConcurrentSend(t *testing.T) {
+	const lobbyID CSteamID = 1000
+	mm := newFakeMatchmaking(1, 1)
+	d := NewCallbackDispatcher()
+	m := NewLobbyMessenger(yieldingMatchmaking{mm}, d, lobbyID)
+	defer m.Close()
+
+	var got []LobbyMessage
+	m.Subscribe(func(msg LobbyMessage) { got = append(got, msg) })
+
+	const senders = 8
+	var wg sync.WaitGroup
+	for i := range senders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			payload := bytes.Repeat([]byte{byte(i)}, 3*lobbyFramePayloadMax)
+			if err := m.Send(uint16(i), 1, payload); err != nil {
+				t.Errorf("Send error=%v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	deliverLobbyChat(d, mm, lobbyID, 1)
+
+	if len(got) != senders {
+		t.Fatalf("messages=%d, want %d", len(got), senders)
+	}
+	for _, msg := range got {
+		if !bytes.Equal(msg.Payload, bytes.Repeat([]byte{byte(msg.Kind)}, 3*lobbyFramePayloadMax)) {
+			t.Fatalf("message of kind %d has a mixed payload", msg.Kind)
+		}
+	}
+}
+
+func TestLobbyMessengerDropsBrokenSequences(t *testing.T) {
+	const lobbyID CSteamID = 1000
+	mm := newFakeMatchmaking(1, 1, 2)
+	d := NewCallbackDispatcher()
+	m := NewLobbyMessenger(mm, d, lobbyID)
+	defer m.Close()
+
+	var got []LobbyMessage
+	m.Subscribe(func(msg LobbyMessage) { got = append(got, msg) })
+
+	big := make([]byte, 2*lobbyFramePayloadMax)
+	if err := m.Send(1, 1, big); err != nil {
+		t.Fatalf("Send error=%v", err)
+	}
+	first := mm.sent
+	mm.sent = nil
+	if err := m.Send(1, 1, big); err != nil {
+		t.Fatalf("Send error=%v", err)
+	}
+	second := mm.sent
+	mm.sent = nil
+
+	// The second chunk of the first message is lost; its successor must not
+	// complete the message, but the next message still arrives.
+	postLobbyChat(d, mm, lobbyID, 2, EChatEntryTypeChatMsg, first[0])
+	postLobbyChat(d, mm, lobbyID, 2, EChatEntryTypeChatMsg, second[1])
+	postLobbyChat(d, mm, lobbyID, 2, EChatEntryTypeChatMsg, second[0])
+	postLobbyChat(d, mm, lobbyID, 2, EChatEntryTypeChatMsg, second[1])
+	if len(got) != 1 || len(got[0].Payload) != len(big) {
+		t.Fatalf("messages=%d, want only the complete second message", len(got))
+	}
+
+	if err := m.Send(1, 1, make([]byte, LobbyMessageMaxSize+1)); !errors.Is(err, ErrLobbyMessageTooLarge) {
+		t.Fatalf("oversized Send error=%v, want %v", err, ErrLobbyMessageTooLarge)
+	}
+}
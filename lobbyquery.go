@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrLobbyListFailed = errors.New("steamworks: lobby list request failed")
+
+// LobbySummary describes a lobby returned by a LobbyQuery.
+type LobbySummary struct {
+	ID          CSteamID
+	Members     int
+	MemberLimit int
+	// Data holds the non-empty values of the keys requested with
+	// LobbyQuery.Keys.
+	Data map[string]string
+}
+
+// LobbyQuery builds a lobby search. Steam resets lobby list filters after
+// every RequestLobbyList, so the query keeps its filters and applies them
+// again on each Run.
+type LobbyQuery struct {
+	mm           ISteamMatchmaking
+	d            *CallbackDispatcher
+	filters      []func(ISteamMatchmaking)
+	keys         []string
+	pollInterval time.Duration
+	err          error
+}
+
+// NewLobbyQuery constructs an empty query. d is used to wait for the data of
+// lobbies Steam returns without it; it may be nil.
+func NewLobbyQuery(mm ISteamMatchmaking, d *CallbackDispatcher) *LobbyQuery {
+	return &LobbyQuery{mm: mm, d: d}
+}
+
+// StringFilter requires lobby data key to compare to value.
+func (q *LobbyQuery) StringFilter(key, value string, comparison ELobbyComparison) *LobbyQuery {
+	return q.add(func(mm ISteamMatchmaking) { mm.AddRequestLobbyListStringFilter(key, value, comparison) })
+}
+
+// NumericFilter requires the numeric lobby data key to compare to value.
+func (q *LobbyQuery) NumericFilter(key string, value int, comparison ELobbyComparison) *LobbyQuery {
+	return q.add(func(mm ISteamMatchmaking) { mm.AddRequestLobbyListNumericalFilter(key, value, comparison) })
+}
+
+// NearFilter sorts results by how close the numeric lobby data key is to value.
+func (q *LobbyQuery) NearFilter(key string, value int) *LobbyQuery {
+	return q.add(func(mm ISteamMatchmaking) { mm.AddRequestLobbyListNearValueFilter(key, value) })
+}
+
+// SlotsAvailable requires at least slots open member slots.
+func (q *LobbyQuery) SlotsAvailable(slots int) *LobbyQuery {
+	return q.add(func(mm ISteamMatchmaking) { mm.AddRequestLobbyListFilterSlotsAvailable(slots) })
+}
+
+// Distance limits results by geographical distance.
+func (q *LobbyQuery) Distance(distance ELobbyDistanceFilter) *LobbyQuery {
+	return q.add(func(mm ISteamMatchmaking) { mm.AddRequestLobbyListDistanceFilter(distance) })
+}
+
+// MaxResults limits the number of returned lobbies.
+func (q *LobbyQuery) MaxResults(n int) *LobbyQuery {
+	return q.add(func(mm ISteamMatchmaking) { mm.AddRequestLobbyListResultCountFilter(n) })
+}
+
+// CompatibleMembers requires lobbies to have a member the local user has
+// played with in lobbyID.
+func (q *LobbyQuery) CompatibleMembers(lobbyID CSteamID) *LobbyQuery {
+	return q.add(func(mm ISteamMatchmaking) { mm.AddRequestLobbyListCompatibleMembersFilter(lobbyID) })
+}
+
+// FilterData adds a filter for each tagged field of v, as used by
+// MarshalLobbyData: integer, bool and time fields become numerical filters and
+// the others string filters. omitempty fields holding their zero value are
+// skipped. An invalid v makes Run fail.
+func (q *LobbyQuery) FilterData(v any, comparison ELobbyComparison) *LobbyQuery {
+	filters, err := lobbyFilters(v, comparison)
+	if err != nil {
+		q.err = errors.Join(q.err, err)
+		return q
+	}
+	q.filters = append(q.filters, filters...)
+	return q
+}
+
+// Keys selects the lobby data keys copied into LobbySummary.Data.
+func (q *LobbyQuery) Keys(keys ...string) *LobbyQuery {
+	q.keys = append(q.keys, keys...)
+	return q
+}
+
+// PollInterval sets how often Run checks for the call result. A value <= 0
+// uses the CallResult.Wait default.
+func (q *LobbyQuery) PollInterval(interval time.Duration) *LobbyQuery {
+	q.pollInterval = interval
+	return q
+}
+
+func (q *LobbyQuery) add(filter func(ISteamMatchmaking)) *LobbyQuery {
+	q.filters = append(q.filters, filter)
+	return q
+}
+
+// Run applies the filters, requests the lobby list and waits for it. When Keys
+// are set and a dispatcher was given, it also waits for the data of lobbies
+// Steam returned without any. If ctx is done during that wait, Run returns
+// every lobby, with the data that arrived in time, along with ctx's error.
+func (q *LobbyQuery) Run(ctx context.Context) ([]LobbySummary, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	q.apply()
+	call := q.mm.RequestLobbyList()
+	if call == 0 {
+		return nil, ErrLobbyListFailed
+	}
+	result, failed, err := NewCallResult[LobbyMatchList](call, int32(CallbackIDLobbyMatchList)).Wait(ctx, q.pollInterval)
+	if err != nil {
+		return nil, err
+	}
+	if failed {
+		return nil, ErrLobbyListFailed
+	}
+	return q.collect(ctx, int(result.LobbiesMatching))
+}
+
+func (q *LobbyQuery) apply() {
+	for _, filter := range q.filters {
+		filter(q.mm)
+	}
+}
+
+func (q *LobbyQuery) collect(ctx context.Context, count int) ([]LobbySummary, error) {
+	ids := make([]CSteamID, 0, count)
+	for i := 0; i < count; i++ {
+		if id := q.mm.GetLobbyByIndex(i); id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	// A canceled fetch still leaves the lobbies and whatever data arrived.
+	err := q.fetchData(ctx, ids)
+
+	summaries := make([]LobbySummary, len(ids))
+	for i, id := range ids {
+		s := LobbySummary{
+			ID:          id,
+			Members:     q.mm.GetNumLobbyMembers(id),
+			MemberLimit: q.mm.GetLobbyMemberLimit(id),
+			Data:        make(map[string]string, len(q.keys)),
+		}
+		for _, key := range q.keys {
+			if v := q.mm.GetLobbyData(id, key); v != "" {
+				s.Data[key] = v
+			}
+		}
+		summaries[i] = s
+	}
+	return summaries, err
+}
+
+// fetchData requests the data of lobbies without any and waits for their
+// LobbyDataUpdate_t.
+func (q *LobbyQuery) fetchData(ctx context.Context, ids []CSteamID) error {
+	if len(q.keys) == 0 || q.d == nil {
+		return nil
+	}
+	var (
+		mu      sync.Mutex
+		pending = make(map[CSteamID]bool)
+		done    = make(chan struct{})
+	)
+	resolve := func(id CSteamID) {
+		mu.Lock()
+		defer mu.Unlock()
+		if pending[id] {
+			delete(pending, id)
+			if len(pending) == 0 {
+				close(done)
+			}
+		}
+	}
+
+	var missing []CSteamID
+	for _, id := range ids {
+		if q.mm.GetLobbyDataCount(id) == 0 {
+			missing = append(missing, id)
+			pending[id] = true
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	remove := AddCallback(q.d, CallbackIDLobbyDataUpdate, func(cb LobbyDataUpdate) {
+		if cb.LobbySteamID == cb.MemberSteamID {
+			resolve(cb.LobbySteamID)
+		}
+	})
+	defer remove()
+
+	for _, id := range missing {
+		if !q.mm.RequestLobbyData(id) {
+			resolve(id)
+		}
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
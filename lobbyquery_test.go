@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+type fakeLobbyList struct {
+	ISteamMatchmaking
+	calls   []string
+	lobbies []CSteamID
+	data    map[CSteamID]map[string]string
+	// delayed holds data Steam delivers only after RequestLobbyData.
+	delayed map[CSteamID]map[string]string
+	d       *CallbackDispatcher
+}
+
+func (f *fakeLobbyList) AddRequestLobbyListStringFilter(key, value string, cmp ELobbyComparison) {
+	f.calls = append(f.calls, fmt.Sprintf("string %s %s %d", key, value, cmp))
+}
+
+func (f *fakeLobbyList) AddRequestLobbyListNumericalFilter(key string, value int, cmp ELobbyComparison) {
+	f.calls = append(f.calls, fmt.Sprintf("numeric %s %d %d", key, value, cmp))
+}
+
+func (f *fakeLobbyList) AddRequestLobbyListNearValueFilter(key string, value int) {
+	f.calls = append(f.calls, fmt.Sprintf("near %s %d", key, value))
+}
+
+func (f *fakeLobbyList) AddRequestLobbyListFilterSlotsAvailable(slots int) {
+	f.calls = append(f.calls, fmt.Sprintf("slots %d", slots))
+}
+
+func (f *fakeLobbyList) AddRequestLobbyListDistanceFilter(distance ELobbyDistanceFilter) {
+	f.calls = append(f.calls, fmt.Sprintf("distance %d", distance))
+}
+
+func (f *fakeLobbyList) AddRequestLobbyListResultCountFilter(n int) {
+	f.calls = append(f.calls, fmt.Sprintf("max %d", n))
+}
+
+func (f *fakeLobbyList) AddRequestLobbyListCompatibleMembersFilter(lobbyID CSteamID) {
+	f.calls = append(f.calls, fmt.Sprintf("compatible %d", lobbyID))
+}
+
+func (f *fakeLobbyList) GetLobbyByIndex(index int) CSteamID { return f.lobbies[index] }
+func (f *fakeLobbyList) GetNumLobbyMembers(CSteamID) int    { return 2 }
+func (f *fakeLobbyList) GetLobbyMemberLimit(CSteamID) int   { return 4 }
+
+func (f *fakeLobbyList) GetLobbyDataCount(id CSteamID) int { return len(f.data[id]) }
+
+func (f *fakeLobbyList) GetLobbyData(id CSteamID, key string) string { return f.data[id][key] }
+
+func (f *fakeLobbyList) RequestLobbyData(id CSteamID) bool {
+	data, ok := f.delayed[id]
+	if !ok {
+		return false
+	}
+	go func() {
+		time.Sleep(time.Millisecond)
+		f.data[id] = data
+		dispatchCallback(f.d, CallbackIDLobbyDataUpdate, LobbyDataUpdate{LobbySteamID: id, MemberSteamID: id, Success: 1})
+	}()
+	return true
+}
+
+func TestLobbyQueryAppliesFiltersInOrder(t *testing.T) {
+	mm := &fakeLobbyList{}
+	q := NewLobbyQuery(mm, nil).
+		StringFilter("mode", "ctf", ELobbyComparisonEqual).
+		NumericFilter("mmr", 1500, ELobbyComparisonEqualToOrGreaterThan).
+		NearFilter("mmr", 1600).
+		SlotsAvailable(2).
+		Distance(ELobbyDistanceFilterWorldwide).
+		MaxResults(10).
+		CompatibleMembers(77)
+
+	q.apply()
+	q.apply()
+	want := []string{
+		"string mode ctf 0",
+		"numeric mmr 1500 2",
+		"near mmr 1600",
+		"slots 2",
+		"distance 3",
+		"max 10",
+		"compatible 77",
+	}
+	if got := mm.calls; !slices.Equal(got, append(slices.Clone(want), want...)) {
+		t.Fatalf("calls=%q, want filters re-applied on every request %q", got, want)
+	}
+}
+
+func TestLobbyQueryCollectFetchesMissingData(t *testing.T) {
+	d := NewCallbackDispatcher()
+	mm := &fakeLobbyList{
+		lobbies: []CSteamID{1, 2},
+		data:    map[CSteamID]map[string]string{1: {"map": "dust", "mode": "ctf"}},
+		delayed: map[CSteamID]map[string]string{2: {"map": "nuke"}},
+		d:       d,
+	}
+	q := NewLobbyQuery(mm, d).Keys("map", "mode")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	got, err := q.collect(ctx, 2)
+	if err != nil {
+		t.Fatalf("collect error=%v", err)
+	}
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 || got[0].Members != 2 || got[0].MemberLimit != 4 {
+		t.Fatalf("summaries=%+v", got)
+	}
+	if !maps.Equal(got[0].Data, map[string]string{"map": "dust", "mode": "ctf"}) || !maps.Equal(got[1].Data, map[string]string{"map": "nuke"}) {
+		t.Fatalf("data=%v %v", got[0].Data, got[1].Data)
+	}
+
+	// Lobbies whose data Steam refuses to request are returned without it.
+	mm.lobbies = []CSteamID{3}
+	got, err = q.collect(ctx, 1)
+	if err != nil || len(got) != 1 || len(got[0].Data) != 0 {
+		t.Fatalf("collect=%+v, %v, want lobby 3 without data", got, err)
+	}
+}
+
+func TestLobbyQueryCollectHonorsContext(t *testing.T) {
+	d := NewCallbackDispatcher()
+	// RequestLobbyData succeeds for lobby 5 but Steam never answers.
+	mm := &neverAnsweringLobbyList{&fakeLobbyList{
+		lobbies: []CSteamID{4, 5},
+		data:    map[CSteamID]map[string]string{4: {"map": "dust"}},
+	}}
+	q := NewLobbyQuery(mm, d).Keys("map")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	got, err := q.collect(ctx, 2)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("collect error=%v, want %v", err, context.DeadlineExceeded)
+	}
+	// The lobbies are still returned, with the data that was available.
+	if len(got) != 2 || got[0].ID != 4 || got[1].ID != 5 {
+		t.Fatalf("summaries=%+v, want lobbies 4 and 5", got)
+	}
+	if !maps.Equal(got[0].Data, map[string]string{"map": "dust"}) || len(got[1].Data) != 0 {
+		t.Fatalf("data=%v %v", got[0].Data, got[1].Data)
+	}
+}
+
+type neverAnsweringLobbyList struct {
+	*fakeLobbyList
+}
+
+func (f *neverAnsweringLobbyList) RequestLobbyData(CSteamID) bool { return true }
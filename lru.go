@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import "container/list"
+
+// lruCache is a fixed-capacity least-recently-used cache. It is not safe for
+// concurrent use.
+type lruCache[K comparable, V any] struct {
+	capacity int
+	order    *list.List
+	items    map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRUCache[K comparable, V any](capacity int) *lruCache[K, V] {
+	return &lruCache[K, V]{
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		items:    make(map[K]*list.Element),
+	}
+}
+
+func (c *lruCache[K, V]) get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry[K, V]).value, true
+}
+
+func (c *lruCache[K, V]) put(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+func (c *lruCache[K, V]) remove(key K) {
+	if e, ok := c.items[key]; ok {
+		c.order.Remove(e)
+		delete(c.items, key)
+	}
+}
+
+func (c *lruCache[K, V]) len() int {
+	return c.order.Len()
+}
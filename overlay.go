@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultOverlayPresentInterval is the frame interval PresentWhileNeeded uses
+// when given a non-positive interval.
+const DefaultOverlayPresentInterval = time.Second / 60
+
+// OverlayEvent reports that the Steam overlay opened or closed.
+type OverlayEvent struct {
+	Open bool
+	// UserInitiated is true when the user opened the overlay with the hotkey,
+	// and false when the game activated it.
+	UserInitiated bool
+	// AppID is the app the overlay was activated for. It differs from the
+	// running app when the overlay shows another game's store page.
+	AppID AppId_t
+}
+
+// Overlay tracks the Steam overlay from GameOverlayActivated_t so games can
+// pause gameplay and input while it is open, and groups the overlay's web page
+// and notification settings.
+type Overlay struct {
+	friends ISteamFriends
+	utils   ISteamUtils
+
+	mu   sync.Mutex
+	last OverlayEvent
+
+	events     eventSource[OverlayEvent]
+	navigation eventSource[string]
+	removes    []func()
+}
+
+// NewOverlay constructs an overlay tracker and subscribes to
+// GameOverlayActivated_t and OverlayBrowserProtocolNavigation_t on d.
+func NewOverlay(friends ISteamFriends, utils ISteamUtils, d *CallbackDispatcher) *Overlay {
+	o := &Overlay{friends: friends, utils: utils}
+	if d != nil {
+		o.removes = append(o.removes,
+			AddCallback(d, CallbackIDGameOverlayActivated, o.onActivated),
+			AddCallback(d, CallbackIDOverlayBrowserProtocolNavigation, func(cb OverlayBrowserProtocolNavigation) {
+				o.navigation.emit(cb.URIString())
+			}),
+		)
+	}
+	return o
+}
+
+// Close unsubscribes the overlay from its dispatcher.
+func (o *Overlay) Close() {
+	for _, remove := range o.removes {
+		remove()
+	}
+	o.removes = nil
+}
+
+// IsEnabled reports whether the overlay is enabled and injected into the game.
+func (o *Overlay) IsEnabled() bool {
+	return o.utils.IsOverlayEnabled()
+}
+
+// IsOpen reports whether the overlay is currently shown.
+func (o *Overlay) IsOpen() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.last.Open
+}
+
+// Last returns the most recent overlay activation change.
+func (o *Overlay) Last() OverlayEvent {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.last
+}
+
+// Subscribe registers fn for overlay open and close changes. Calling the
+// returned function unsubscribes it.
+func (o *Overlay) Subscribe(fn func(OverlayEvent)) (unsubscribe func()) {
+	return o.events.subscribe(fn)
+}
+
+// SubscribeNavigation registers fn for URIs the overlay browser navigated to
+// through a protocol the game registered, typically after OpenWebPage. Calling
+// the returned function unsubscribes it.
+func (o *Overlay) SubscribeNavigation(fn func(uri string)) (unsubscribe func()) {
+	return o.navigation.subscribe(fn)
+}
+
+// OpenWebPage opens url in the overlay browser.
+func (o *Overlay) OpenWebPage(url string, mode EActivateGameOverlayToWebPageMode) {
+	o.friends.ActivateGameOverlayToWebPage(url, mode)
+}
+
+// SetNotificationPosition sets the corner Steam notifications appear in.
+func (o *Overlay) SetNotificationPosition(position ENotificationPosition) {
+	o.utils.SetOverlayNotificationPosition(position)
+}
+
+// SetNotificationInset offsets notifications from their corner, in pixels.
+func (o *Overlay) SetNotificationInset(horizontal, vertical int32) {
+	o.utils.SetOverlayNotificationInset(horizontal, vertical)
+}
+
+// NeedsPresent reports whether the overlay needs the game to present a frame.
+func (o *Overlay) NeedsPresent() bool {
+	return o.utils.BOverlayNeedsPresent()
+}
+
+// PresentWhileNeeded calls present every interval for as long as
+// BOverlayNeedsPresent reports true, for renderers that stop presenting frames
+// while paused. It returns nil once the overlay no longer needs frames, or
+// ctx.Err() when ctx is done first.
+func (o *Overlay) PresentWhileNeeded(ctx context.Context, interval time.Duration, present func()) error {
+	if interval <= 0 {
+		interval = DefaultOverlayPresentInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for o.utils.BOverlayNeedsPresent() {
+		present()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func (o *Overlay) onActivated(cb GameOverlayActivated) {
+	e := OverlayEvent{Open: cb.Active != 0, UserInitiated: cb.UserInitiated, AppID: cb.AppID}
+	o.mu.Lock()
+	o.last = e
+	o.mu.Unlock()
+	o.events.emit(e)
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+type fakeOverlayUtils struct {
+	ISteamUtils
+	needsPresent int
+	position     ENotificationPosition
+	inset        [2]int32
+}
+
+func (f *fakeOverlayUtils) BOverlayNeedsPresent() bool {
+	if f.needsPresent > 0 {
+		f.needsPresent--
+		return true
+	}
+	return f.needsPresent < 0
+}
+
+func (f *fakeOverlayUtils) SetOverlayNotificationPosition(p ENotificationPosition) { f.position = p }
+func (f *fakeOverlayUtils) SetOverlayNotificationInset(h, v int32)                 { f.inset = [2]int32{h, v} }
+
+type fakeOverlayFriends struct {
+	ISteamFriends
+	url string
+}
+
+func (f *fakeOverlayFriends) ActivateGameOverlayToWebPage(url string, _ EActivateGameOverlayToWebPageMode) {
+	f.url = url
+}
+
+func TestOverlayTracksActivation(t *testing.T) {
+	d := NewCallbackDispatcher()
+	o := NewOverlay(&fakeOverlayFriends{}, &fakeOverlayUtils{}, d)
+	defer o.Close()
+
+	var events []OverlayEvent
+	o.Subscribe(func(e OverlayEvent) { events = append(events, e) })
+
+	dispatchCallback(d, CallbackIDGameOverlayActivated, GameOverlayActivated{Active: 1, UserInitiated: true, AppID: 480})
+	if !o.IsOpen() {
+		t.Fatalf("IsOpen()=false after activation")
+	}
+	dispatchCallback(d, CallbackIDGameOverlayActivated, GameOverlayActivated{AppID: 480})
+	if o.IsOpen() {
+		t.Fatalf("IsOpen()=true after deactivation")
+	}
+
+	want := []OverlayEvent{{Open: true, UserInitiated: true, AppID: 480}, {AppID: 480}}
+	if len(events) != len(want) || events[0] != want[0] || events[1] != want[1] {
+		t.Fatalf("events=%+v, want %+v", events, want)
+	}
+}
+
+func TestOverlayWebPageAndNotifications(t *testing.T) {
+	d := NewCallbackDispatcher()
+	friends := &fakeOverlayFriends{}
+	utils := &fakeOverlayUtils{}
+	o := NewOverlay(friends, utils, d)
+	defer o.Close()
+
+	var uris []string
+	o.SubscribeNavigation(func(uri string) { uris = append(uris, uri) })
+
+	o.OpenWebPage("https://example.com/checkout", EActivateGameOverlayToWebPageMode_Modal)
+	var nav OverlayBrowserProtocolNavigation
+	copy(nav.URI[:], "steam://gamewebcallback/done")
+	dispatchCallback(d, CallbackIDOverlayBrowserProtocolNavigation, nav)
+
+	if friends.url != "https://example.com/checkout" || len(uris) != 1 || uris[0] != "steam://gamewebcallback/done" {
+		t.Fatalf("url=%q uris=%q", friends.url, uris)
+	}
+
+	o.SetNotificationPosition(ENotificationPositionTopLeft)
+	o.SetNotificationInset(16, 32)
+	if utils.position != ENotificationPositionTopLeft || utils.inset != [2]int32{16, 32} {
+		t.Fatalf("position=%d inset=%v", utils.position, utils.inset)
+	}
+}
+
+func TestOverlayPresentWhileNeeded(t *testing.T) {
+	utils := &fakeOverlayUtils{needsPresent: 3}
+	o := NewOverlay(&fakeOverlayFriends{}, utils, nil)
+
+	frames := 0
+	if err := o.PresentWhileNeeded(context.Background(), time.Millisecond, func() { frames++ }); err != nil {
+		t.Fatalf("PresentWhileNeeded error=%v", err)
+	}
+	if frames != 3 {
+		t.Fatalf("presented %d frames, want 3", frames)
+	}
+
+	utils.needsPresent = -1
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := o.PresentWhileNeeded(ctx, time.Millisecond, func() {}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error=%v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestGameOverlayActivatedLayout(t *testing.T) {
+	var cb GameOverlayActivated
+	if got, want := unsafe.Offsetof(cb.AppID), uintptr(4); got != want {
+		t.Fatalf("GameOverlayActivated.AppID offset=%d, want %d", got, want)
+	}
+	if got, want := unsafe.Sizeof(cb), uintptr(12); got != want {
+		t.Fatalf("GameOverlayActivated size=%d, want %d", got, want)
+	}
+}
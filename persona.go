@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// personaPollInterval bounds how long RequestUserInformation waits between
+// availability checks when no callback wakes it.
+const personaPollInterval = 100 * time.Millisecond
+
+// Persona is a snapshot of a user's public profile state.
+type Persona struct {
+	SteamID    CSteamID
+	Name       string
+	State      EPersonaState
+	InGame     bool
+	GamePlayed FriendGameInfo
+	SteamLevel int
+	// SmallAvatar and MediumAvatar are image handles for ISteamUtils.GetImageRGBA.
+	// Large avatars are downloaded on demand; use AvatarLoader for them.
+	SmallAvatar  int32
+	MediumAvatar int32
+}
+
+// PersonaEvent reports a persona update and which parts of it changed.
+type PersonaEvent struct {
+	Persona Persona
+	Changes EPersonaChange
+}
+
+// PersonaCache holds persona state for any user, friend or not, and updates
+// it from PersonaStateChange_t callbacks delivered through the dispatcher.
+type PersonaCache struct {
+	friends ISteamFriends
+
+	mu       sync.Mutex
+	personas map[CSteamID]Persona
+	wake     chan struct{}
+
+	events eventSource[PersonaEvent]
+	remove func()
+}
+
+// NewPersonaCache constructs a cache and subscribes to PersonaStateChange_t on
+// d. d may be nil, in which case RequestUserInformation polls.
+func NewPersonaCache(friends ISteamFriends, d *CallbackDispatcher) *PersonaCache {
+	c := &PersonaCache{
+		friends:  friends,
+		personas: make(map[CSteamID]Persona),
+		wake:     make(chan struct{}),
+	}
+	if d != nil {
+		c.remove = AddCallback(d, CallbackIDPersonaStateChange, c.onPersonaStateChange)
+	}
+	return c
+}
+
+// Close unsubscribes the cache from its dispatcher.
+func (c *PersonaCache) Close() {
+	if c.remove != nil {
+		c.remove()
+	}
+}
+
+// Subscribe registers fn for persona updates. Calling the returned function
+// unsubscribes it.
+func (c *PersonaCache) Subscribe(fn func(PersonaEvent)) (unsubscribe func()) {
+	return c.events.subscribe(fn)
+}
+
+// Get returns the cached persona of id without querying Steam.
+func (c *PersonaCache) Get(id CSteamID) (Persona, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.personas[id]
+	return p, ok
+}
+
+// Lookup reads the current persona of id from Steam and caches it. Names of
+// users Steam has no data for yet read as "[unknown]"; use
+// RequestUserInformation to wait for them.
+func (c *PersonaCache) Lookup(id CSteamID) Persona {
+	p := c.read(id)
+	c.mu.Lock()
+	c.personas[id] = p
+	c.mu.Unlock()
+	return p
+}
+
+// RequestUserInformation asks Steam for the persona of id, waits until it is
+// available or ctx is done, and returns it. With nameOnly, Steam skips
+// downloading the avatar. Callbacks must keep being pumped while it waits.
+func (c *PersonaCache) RequestUserInformation(ctx context.Context, id CSteamID, nameOnly bool) (Persona, error) {
+	ticker := time.NewTicker(personaPollInterval)
+	defer ticker.Stop()
+
+	for {
+		c.mu.Lock()
+		wake := c.wake
+		c.mu.Unlock()
+
+		// RequestUserInformation returns false once the data is available.
+		if !c.friends.RequestUserInformation(id, nameOnly) {
+			return c.Lookup(id), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return Persona{}, ctx.Err()
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *PersonaCache) read(id CSteamID) Persona {
+	p := Persona{SteamID: id}
+	c.readName(&p)
+	c.readState(&p)
+	c.readGame(&p)
+	c.readLevel(&p)
+	c.readAvatars(&p)
+	return p
+}
+
+func (c *PersonaCache) readName(p *Persona)  { p.Name = c.friends.GetFriendPersonaName(p.SteamID) }
+func (c *PersonaCache) readState(p *Persona) { p.State = c.friends.GetFriendPersonaState(p.SteamID) }
+func (c *PersonaCache) readLevel(p *Persona) { p.SteamLevel = c.friends.GetFriendSteamLevel(p.SteamID) }
+
+func (c *PersonaCache) readGame(p *Persona) {
+	p.GamePlayed, p.InGame = c.friends.GetFriendGamePlayed(p.SteamID)
+}
+
+func (c *PersonaCache) readAvatars(p *Persona) {
+	p.SmallAvatar = c.friends.GetSmallFriendAvatar(p.SteamID)
+	p.MediumAvatar = c.friends.GetMediumFriendAvatar(p.SteamID)
+}
+
+// onPersonaStateChange re-reads only the fields named by the change flags of
+// users already in the cache, and reads new users in full.
+func (c *PersonaCache) onPersonaStateChange(cb PersonaStateChange) {
+	c.mu.Lock()
+	p, known := c.personas[cb.SteamID]
+	c.mu.Unlock()
+
+	if !known {
+		p = c.read(cb.SteamID)
+	} else {
+		flags := cb.ChangeFlags
+		if flags&(EPersonaChangeName|EPersonaChangeNameFirstSet|EPersonaChangeNickname) != 0 {
+			c.readName(&p)
+		}
+		if flags&(EPersonaChangeStatus|EPersonaChangeComeOnline|EPersonaChangeGoneOffline) != 0 {
+			c.readState(&p)
+		}
+		if flags&(EPersonaChangeGamePlayed|EPersonaChangeGameServer) != 0 {
+			c.readGame(&p)
+		}
+		if flags.Has(EPersonaChangeSteamLevel) {
+			c.readLevel(&p)
+		}
+		if flags.Has(EPersonaChangeAvatar) {
+			c.readAvatars(&p)
+		}
+	}
+
+	c.mu.Lock()
+	c.personas[cb.SteamID] = p
+	close(c.wake)
+	c.wake = make(chan struct{})
+	c.mu.Unlock()
+
+	c.events.emit(PersonaEvent{Persona: p, Changes: cb.ChangeFlags})
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+type fakePersonaFriends struct {
+	ISteamFriends
+	mu        sync.Mutex
+	personas  map[CSteamID]Persona
+	pending   map[CSteamID]bool
+	nameReads int
+}
+
+func (f *fakePersonaFriends) get(id CSteamID) Persona {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.personas[id]
+}
+
+func (f *fakePersonaFriends) GetFriendPersonaName(id CSteamID) string {
+	f.mu.Lock()
+	f.nameReads++
+	f.mu.Unlock()
+	if name := f.get(id).Name; name != "" {
+		return name
+	}
+	return "[unknown]"
+}
+
+func (f *fakePersonaFriends) GetFriendPersonaState(id CSteamID) EPersonaState { return f.get(id).State }
+func (f *fakePersonaFriends) GetFriendSteamLevel(id CSteamID) int             { return f.get(id).SteamLevel }
+func (f *fakePersonaFriends) GetSmallFriendAvatar(id CSteamID) int32          { return f.get(id).SmallAvatar }
+func (f *fakePersonaFriends) GetMediumFriendAvatar(id CSteamID) int32         { return f.get(id).MediumAvatar }
+
+func (f *fakePersonaFriends) GetFriendGamePlayed(id CSteamID) (FriendGameInfo, bool) {
+	p := f.get(id)
+	return p.GamePlayed, p.InGame
+}
+
+func (f *fakePersonaFriends) RequestUserInformation(id CSteamID, nameOnly bool) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pending[id]
+}
+
+func (f *fakePersonaFriends) update(p Persona) {
+	f.mu.Lock()
+	f.personas[p.SteamID] = p
+	delete(f.pending, p.SteamID)
+	f.mu.Unlock()
+}
+
+func TestPersonaCacheRequestUserInformation(t *testing.T) {
+	friends := &fakePersonaFriends{personas: map[CSteamID]Persona{}, pending: map[CSteamID]bool{7: true}}
+	d := NewCallbackDispatcher()
+	c := NewPersonaCache(friends, d)
+	defer c.Close()
+
+	done := make(chan Persona, 1)
+	go func() {
+		p, err := c.RequestUserInformation(context.Background(), 7, true)
+		if err != nil {
+			t.Errorf("RequestUserInformation error=%v", err)
+		}
+		done <- p
+	}()
+	time.Sleep(10 * time.Millisecond)
+	friends.update(Persona{SteamID: 7, Name: "lobby member", State: EPersonaStateOnline})
+	dispatchCallback(d, CallbackIDPersonaStateChange, PersonaStateChange{SteamID: 7, ChangeFlags: EPersonaChangeNameFirstSet})
+
+	select {
+	case p := <-done:
+		if p.Name != "lobby member" || p.State != EPersonaStateOnline {
+			t.Fatalf("RequestUserInformation=%+v, want lobby member online", p)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("RequestUserInformation did not return after PersonaStateChange")
+	}
+
+	friends.mu.Lock()
+	friends.pending[8] = true
+	friends.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.RequestUserInformation(ctx, 8, false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error=%v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestPersonaCacheAppliesChangeFlags(t *testing.T) {
+	friends := &fakePersonaFriends{personas: map[CSteamID]Persona{
+		1: {SteamID: 1, Name: "alice", State: EPersonaStateOnline, SteamLevel: 10},
+	}}
+	d := NewCallbackDispatcher()
+	c := NewPersonaCache(friends, d)
+	defer c.Close()
+
+	var events []PersonaEvent
+	c.Subscribe(func(e PersonaEvent) { events = append(events, e) })
+
+	if got := c.Lookup(1); got.Name != "alice" || got.SteamLevel != 10 {
+		t.Fatalf("Lookup=%+v, want alice level 10", got)
+	}
+	friends.update(Persona{SteamID: 1, Name: "alice2", State: EPersonaStateAway, SteamLevel: 11, InGame: true, GamePlayed: FriendGameInfo{GameID: 480}})
+	friends.nameReads = 0
+	dispatchCallback(d, CallbackIDPersonaStateChange, PersonaStateChange{SteamID: 1, ChangeFlags: EPersonaChangeStatus | EPersonaChangeGamePlayed})
+
+	got, _ := c.Get(1)
+	if got.Name != "alice" || got.State != EPersonaStateAway || got.SteamLevel != 10 || !got.InGame || got.GamePlayed.GameID != 480 {
+		t.Fatalf("Get=%+v, want only status and game updated", got)
+	}
+	if friends.nameReads != 0 {
+		t.Fatalf("name re-read %d times for status change", friends.nameReads)
+	}
+	if len(events) != 1 || events[0].Changes != EPersonaChangeStatus|EPersonaChangeGamePlayed {
+		t.Fatalf("events=%+v, want one status/game change", events)
+	}
+
+	dispatchCallback(d, CallbackIDPersonaStateChange, PersonaStateChange{SteamID: 2, ChangeFlags: EPersonaChangeName})
+	if _, ok := c.Get(2); !ok {
+		t.Fatalf("unknown user not cached from PersonaStateChange")
+	}
+}
+
+func TestPersonaStateChangeLayout(t *testing.T) {
+	var cb PersonaStateChange
+	if got, want := unsafe.Offsetof(cb.ChangeFlags), uintptr(8); got != want {
+		t.Fatalf("PersonaStateChange.ChangeFlags offset=%d, want %d", got, want)
+	}
+	var loaded AvatarImageLoaded
+	if got, want := unsafe.Offsetof(loaded.Tall), uintptr(16); got != want {
+		t.Fatalf("AvatarImageLoaded.Tall offset=%d, want %d", got, want)
+	}
+}
@@ -4,6 +4,7 @@
 package steamworks
 
 import (
+	"bytes"
 	"unsafe"
 
 	"github.com/ebitengine/purego"
@@ -112,3 +113,202 @@ func SteamEncryptedAppTicketGetTicketSteamID(decryptedTicket []byte) (CSteamID,
 	)
 	return id, r1 != 0
 }
+
+// SteamEncryptedAppTicketGetTicketAppID returns the app ID a decrypted ticket was issued for.
+func SteamEncryptedAppTicketGetTicketAppID(decryptedTicket []byte) AppId_t {
+	if len(decryptedTicket) == 0 {
+		return 0
+	}
+	ptr, err := LookupSymbol("SteamEncryptedAppTicket_GetTicketAppID")
+	if err != nil {
+		return 0
+	}
+	r1, _, _ := purego.SyscallN(
+		ptr,
+		uintptr(unsafe.Pointer(&decryptedTicket[0])),
+		uintptr(uint32(len(decryptedTicket))),
+	)
+	return AppId_t(r1)
+}
+
+// SteamEncryptedAppTicketBUserOwnsAppInTicket checks whether the ticket grants ownership of an app ID.
+func SteamEncryptedAppTicketBUserOwnsAppInTicket(decryptedTicket []byte, appID AppId_t) bool {
+	if len(decryptedTicket) == 0 {
+		return false
+	}
+	ptr, err := LookupSymbol("SteamEncryptedAppTicket_BUserOwnsAppInTicket")
+	if err != nil {
+		return false
+	}
+	r1, _, _ := purego.SyscallN(
+		ptr,
+		uintptr(unsafe.Pointer(&decryptedTicket[0])),
+		uintptr(uint32(len(decryptedTicket))),
+		uintptr(appID),
+	)
+	return r1 != 0
+}
+
+// SteamEncryptedAppTicketBUserIsVacBanned reports whether the ticket owner is VAC banned.
+func SteamEncryptedAppTicketBUserIsVacBanned(decryptedTicket []byte) bool {
+	return encryptedAppTicketFlag("SteamEncryptedAppTicket_BUserIsVacBanned", decryptedTicket)
+}
+
+// SteamEncryptedAppTicketBIsLicenseBorrowed reports whether the ticket's license is borrowed via Family Sharing.
+func SteamEncryptedAppTicketBIsLicenseBorrowed(decryptedTicket []byte) bool {
+	return encryptedAppTicketFlag("SteamEncryptedAppTicket_BIsLicenseBorrowed", decryptedTicket)
+}
+
+// SteamEncryptedAppTicketBIsLicenseTemporary reports whether the ticket's license is temporary.
+func SteamEncryptedAppTicketBIsLicenseTemporary(decryptedTicket []byte) bool {
+	return encryptedAppTicketFlag("SteamEncryptedAppTicket_BIsLicenseTemporary", decryptedTicket)
+}
+
+// SteamEncryptedAppTicketBGetAppDefinedValue returns the app-defined value stored in a decrypted ticket.
+func SteamEncryptedAppTicketBGetAppDefinedValue(decryptedTicket []byte) (value uint32, ok bool) {
+	if len(decryptedTicket) == 0 {
+		return 0, false
+	}
+	ptr, err := LookupSymbol("SteamEncryptedAppTicket_BGetAppDefinedValue")
+	if err != nil {
+		return 0, false
+	}
+	r1, _, _ := purego.SyscallN(
+		ptr,
+		uintptr(unsafe.Pointer(&decryptedTicket[0])),
+		uintptr(uint32(len(decryptedTicket))),
+		uintptr(unsafe.Pointer(&value)),
+	)
+	return value, r1 != 0
+}
+
+// SteamEncryptedAppTicketGetAppDefinedValue returns the app-defined value stored in a decrypted ticket,
+// or 0 if none is present. Libraries that do not export the accessor fall back to
+// SteamEncryptedAppTicketBGetAppDefinedValue.
+func SteamEncryptedAppTicketGetAppDefinedValue(decryptedTicket []byte) uint32 {
+	if len(decryptedTicket) == 0 {
+		return 0
+	}
+	ptr, err := LookupSymbol("SteamEncryptedAppTicket_GetAppDefinedValue")
+	if err != nil {
+		value, _ := SteamEncryptedAppTicketBGetAppDefinedValue(decryptedTicket)
+		return value
+	}
+	r1, _, _ := purego.SyscallN(
+		ptr,
+		uintptr(unsafe.Pointer(&decryptedTicket[0])),
+		uintptr(uint32(len(decryptedTicket))),
+	)
+	return uint32(r1)
+}
+
+// SteamEncryptedAppTicketGetUserVariableData returns a copy of the data included via RequestEncryptedAppTicket.
+func SteamEncryptedAppTicketGetUserVariableData(decryptedTicket []byte) []byte {
+	if len(decryptedTicket) == 0 {
+		return nil
+	}
+	ptr, err := LookupSymbol("SteamEncryptedAppTicket_GetUserVariableData")
+	if err != nil {
+		return nil
+	}
+	var size uint32
+	r1, _, _ := purego.SyscallN(
+		ptr,
+		uintptr(unsafe.Pointer(&decryptedTicket[0])),
+		uintptr(uint32(len(decryptedTicket))),
+		uintptr(unsafe.Pointer(&size)),
+	)
+	if r1 == 0 || size == 0 {
+		return nil
+	}
+	// The returned pointer aliases decryptedTicket, so rebase it onto the Go slice.
+	offset := r1 - uintptr(unsafe.Pointer(&decryptedTicket[0]))
+	if offset >= uintptr(len(decryptedTicket)) || uintptr(size) > uintptr(len(decryptedTicket))-offset {
+		return nil
+	}
+	return bytes.Clone(decryptedTicket[offset : offset+uintptr(size)])
+}
+
+// SteamEncryptedAppTicketBIsTicketSigned verifies the ticket signature against a DER-encoded RSA public key.
+func SteamEncryptedAppTicketBIsTicketSigned(decryptedTicket []byte, rsaKey []byte) bool {
+	if len(decryptedTicket) == 0 || len(rsaKey) == 0 {
+		return false
+	}
+	ptr, err := LookupSymbol("SteamEncryptedAppTicket_BIsTicketSigned")
+	if err != nil {
+		return false
+	}
+	r1, _, _ := purego.SyscallN(
+		ptr,
+		uintptr(unsafe.Pointer(&decryptedTicket[0])),
+		uintptr(uint32(len(decryptedTicket))),
+		uintptr(unsafe.Pointer(&rsaKey[0])),
+		uintptr(uint32(len(rsaKey))),
+	)
+	return r1 != 0
+}
+
+func encryptedAppTicketFlag(symbol string, decryptedTicket []byte) bool {
+	if len(decryptedTicket) == 0 {
+		return false
+	}
+	ptr, err := LookupSymbol(symbol)
+	if err != nil {
+		return false
+	}
+	r1, _, _ := purego.SyscallN(
+		ptr,
+		uintptr(unsafe.Pointer(&decryptedTicket[0])),
+		uintptr(uint32(len(decryptedTicket))),
+	)
+	return r1 != 0
+}
+
+// encryptedAppTicketMaxDecryptedSize bounds the buffer used by DecryptAppTicket.
+const encryptedAppTicketMaxDecryptedSize = 1024
+
+// DecryptedAppTicket gathers the fields of a decrypted encrypted app ticket.
+type DecryptedAppTicket struct {
+	SteamID            CSteamID
+	AppID              AppId_t
+	IssueTime          uint32
+	VACBanned          bool
+	LicenseBorrowed    bool
+	LicenseTemporary   bool
+	AppDefinedValue    uint32
+	HasAppDefinedValue bool
+	UserData           []byte
+
+	// Raw is the decrypted ticket, suitable for the SteamEncryptedAppTicket* accessors.
+	Raw []byte
+}
+
+// OwnsApp reports whether the ticket grants ownership of appID.
+func (t DecryptedAppTicket) OwnsApp(appID AppId_t) bool {
+	return SteamEncryptedAppTicketBUserOwnsAppInTicket(t.Raw, appID)
+}
+
+// DecryptAppTicket decrypts an encrypted app ticket and reads every accessor in one call.
+func DecryptAppTicket(ticket []byte, key []byte) (DecryptedAppTicket, bool) {
+	decrypted := make([]byte, encryptedAppTicketMaxDecryptedSize)
+	size, ok := SteamEncryptedAppTicketBDecryptTicket(ticket, decrypted, key)
+	if !ok || size == 0 || int(size) > len(decrypted) {
+		return DecryptedAppTicket{}, false
+	}
+	decrypted = decrypted[:size]
+
+	steamID, _ := SteamEncryptedAppTicketGetTicketSteamID(decrypted)
+	value, hasValue := SteamEncryptedAppTicketBGetAppDefinedValue(decrypted)
+	return DecryptedAppTicket{
+		SteamID:            steamID,
+		AppID:              SteamEncryptedAppTicketGetTicketAppID(decrypted),
+		IssueTime:          SteamEncryptedAppTicketGetTicketIssueTime(decrypted),
+		VACBanned:          SteamEncryptedAppTicketBUserIsVacBanned(decrypted),
+		LicenseBorrowed:    SteamEncryptedAppTicketBIsLicenseBorrowed(decrypted),
+		LicenseTemporary:   SteamEncryptedAppTicketBIsLicenseTemporary(decrypted),
+		AppDefinedValue:    value,
+		HasAppDefinedValue: hasValue,
+		UserData:           SteamEncryptedAppTicketGetUserVariableData(decrypted),
+		Raw:                decrypted,
+	}, true
+}
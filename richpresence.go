@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Rich presence limits enforced by Steam. Key and value lengths are buffer
+// sizes in bytes, including the NUL terminator.
+const (
+	RichPresenceMaxKeys        = 30
+	RichPresenceMaxKeyLength   = 64
+	RichPresenceMaxValueLength = 256
+)
+
+// richPresenceMaxTokenDepth bounds how deeply {#token} substitutions nest
+// during validation.
+const richPresenceMaxTokenDepth = 8
+
+// RichPresenceDisplayKey is the key whose value selects the localization
+// token shown in the Steam client.
+const RichPresenceDisplayKey = "steam_display"
+
+var (
+	ErrRichPresenceTooManyKeys      = errors.New("steamworks: too many rich presence keys")
+	ErrRichPresenceKeyInvalid       = errors.New("steamworks: invalid rich presence key")
+	ErrRichPresenceValueTooLong     = errors.New("steamworks: rich presence value too long")
+	ErrRichPresenceDisplayNotToken  = errors.New("steamworks: steam_display must reference a #token")
+	ErrRichPresenceUnknownToken     = errors.New("steamworks: rich presence token not in localization")
+	ErrRichPresenceMissingKey       = errors.New("steamworks: rich presence placeholder names an unset key")
+	ErrRichPresenceTokenCycle       = errors.New("steamworks: rich presence tokens nest cyclically or too deeply")
+	ErrRichPresenceRejected         = errors.New("steamworks: SetRichPresence rejected")
+	ErrRichPresenceLocalizationFile = errors.New("steamworks: malformed rich presence localization")
+)
+
+var (
+	richPresencePlaceholder = regexp.MustCompile(`%([^%\s]+)%`)
+	richPresenceSubToken    = regexp.MustCompile(`\{(#[^{}]+)\}`)
+)
+
+// RichPresenceLocalization holds the tokens of an app's rich presence
+// localization files, keyed by language.
+type RichPresenceLocalization struct {
+	languages map[string]map[string]string
+}
+
+// LoadRichPresenceLocalization reads one or more rich presence localization
+// .vdf files, as uploaded on the Steamworks partner site.
+func LoadRichPresenceLocalization(paths ...string) (*RichPresenceLocalization, error) {
+	loc := &RichPresenceLocalization{languages: make(map[string]map[string]string)}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := loc.add(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return loc, nil
+}
+
+// ParseRichPresenceLocalization parses rich presence localization data. Both
+// the single-language form ("lang" { "Language" "english" "Tokens" { ... } })
+// and the combined form ("richpresence" { "english" { "tokens" { ... } } })
+// are accepted.
+func ParseRichPresenceLocalization(data []byte) (*RichPresenceLocalization, error) {
+	loc := &RichPresenceLocalization{languages: make(map[string]map[string]string)}
+	if err := loc.add(data); err != nil {
+		return nil, err
+	}
+	return loc, nil
+}
+
+func (l *RichPresenceLocalization) add(data []byte) error {
+	root, err := parseVDF(data)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, top := range root.children {
+		if lang := top.child("Language"); lang != nil {
+			if tokens := top.child("Tokens"); tokens != nil {
+				l.addTokens(lang.value, tokens)
+				found = true
+			}
+			continue
+		}
+		for _, lang := range top.children {
+			if tokens := lang.child("Tokens"); tokens != nil {
+				l.addTokens(lang.key, tokens)
+				found = true
+			}
+		}
+	}
+	if !found {
+		return ErrRichPresenceLocalizationFile
+	}
+	return nil
+}
+
+func (l *RichPresenceLocalization) addTokens(language string, tokens *vdfNode) {
+	language = strings.ToLower(language)
+	m := l.languages[language]
+	if m == nil {
+		m = make(map[string]string)
+		l.languages[language] = m
+	}
+	for _, t := range tokens.children {
+		m[strings.ToLower(t.key)] = t.value
+	}
+}
+
+// Languages returns the loaded languages in sorted order.
+func (l *RichPresenceLocalization) Languages() []string {
+	return slices.Sorted(maps.Keys(l.languages))
+}
+
+// Token returns the text of token in language. Tokens match case-insensitively.
+func (l *RichPresenceLocalization) Token(language, token string) (string, bool) {
+	text, ok := l.languages[strings.ToLower(language)][strings.ToLower(token)]
+	return text, ok
+}
+
+// RichPresence builds the local user's rich presence, validates it against
+// Steam's limits and an optional localization, and sends only changed keys.
+type RichPresence struct {
+	friends      ISteamFriends
+	localization *RichPresenceLocalization
+
+	pending map[string]string
+	sent    map[string]string
+}
+
+// NewRichPresence constructs a builder on top of friends.SetRichPresence.
+func NewRichPresence(friends ISteamFriends) *RichPresence {
+	return &RichPresence{
+		friends: friends,
+		pending: make(map[string]string),
+		sent:    make(map[string]string),
+	}
+}
+
+// WithLocalization enables token and placeholder checks against loc.
+func (r *RichPresence) WithLocalization(loc *RichPresenceLocalization) *RichPresence {
+	r.localization = loc
+	return r
+}
+
+// Set stages key=value. An empty value removes the key.
+func (r *RichPresence) Set(key, value string) *RichPresence {
+	if value == "" {
+		delete(r.pending, key)
+		return r
+	}
+	r.pending[key] = value
+	return r
+}
+
+// Display stages steam_display with the given #token.
+func (r *RichPresence) Display(token string) *RichPresence {
+	return r.Set(RichPresenceDisplayKey, token)
+}
+
+// Delete removes a staged key.
+func (r *RichPresence) Delete(key string) *RichPresence {
+	delete(r.pending, key)
+	return r
+}
+
+// Reset removes every staged key.
+func (r *RichPresence) Reset() *RichPresence {
+	clear(r.pending)
+	return r
+}
+
+// Values returns a copy of the staged keys.
+func (r *RichPresence) Values() map[string]string {
+	return maps.Clone(r.pending)
+}
+
+// Validate checks the staged keys against Steam's limits and, when a
+// localization is set, checks that steam_display names an existing token
+// whose %placeholders% and {#sub-tokens} resolve in every loaded language.
+func (r *RichPresence) Validate() error {
+	if len(r.pending) > RichPresenceMaxKeys {
+		return fmt.Errorf("%w: %d > %d", ErrRichPresenceTooManyKeys, len(r.pending), RichPresenceMaxKeys)
+	}
+	for _, key := range slices.Sorted(maps.Keys(r.pending)) {
+		if key == "" || len(key) >= RichPresenceMaxKeyLength || strings.ContainsAny(key, " \t\r\n%") {
+			return fmt.Errorf("%w: %q", ErrRichPresenceKeyInvalid, key)
+		}
+		if value := r.pending[key]; len(value) >= RichPresenceMaxValueLength {
+			return fmt.Errorf("%w: %q (%d bytes)", ErrRichPresenceValueTooLong, key, len(value))
+		}
+	}
+
+	display, ok := r.pending[RichPresenceDisplayKey]
+	if !ok {
+		return nil
+	}
+	if !strings.HasPrefix(display, "#") {
+		return fmt.Errorf("%w: %q", ErrRichPresenceDisplayNotToken, display)
+	}
+	if r.localization == nil {
+		return nil
+	}
+	for _, language := range r.localization.Languages() {
+		if err := r.validateToken(language, display, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateToken checks token and the {#tokens} it substitutes. path holds the
+// tokens being expanded, so a token that includes itself is reported instead
+// of recursing forever.
+func (r *RichPresence) validateToken(language, token string, path []string) error {
+	if slices.Contains(path, token) || len(path) >= richPresenceMaxTokenDepth {
+		return fmt.Errorf("%w: %s (%s)", ErrRichPresenceTokenCycle, strings.Join(append(path, token), " -> "), language)
+	}
+	path = append(path, token)
+	text, ok := r.localization.Token(language, token)
+	if !ok {
+		return fmt.Errorf("%w: %s (%s)", ErrRichPresenceUnknownToken, token, language)
+	}
+	for _, m := range richPresencePlaceholder.FindAllStringSubmatch(text, -1) {
+		if _, ok := r.pending[m[1]]; !ok {
+			return fmt.Errorf("%w: %%%s%% in %s (%s)", ErrRichPresenceMissingKey, m[1], token, language)
+		}
+	}
+	for _, m := range richPresenceSubToken.FindAllStringSubmatch(text, -1) {
+		sub := richPresencePlaceholder.ReplaceAllStringFunc(m[1], func(p string) string {
+			return r.pending[strings.Trim(p, "%")]
+		})
+		if err := r.validateToken(language, sub, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit validates the staged keys and sends the keys that differ from the
+// last commit. Keys removed since then are cleared.
+func (r *RichPresence) Commit() error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if len(r.pending) == 0 {
+		if len(r.sent) > 0 {
+			r.friends.ClearRichPresence()
+			clear(r.sent)
+		}
+		return nil
+	}
+	for _, key := range slices.Sorted(maps.Keys(r.sent)) {
+		if _, ok := r.pending[key]; ok {
+			continue
+		}
+		if !r.friends.SetRichPresence(key, "") {
+			return fmt.Errorf("%w: clearing %q", ErrRichPresenceRejected, key)
+		}
+		delete(r.sent, key)
+	}
+	for _, key := range slices.Sorted(maps.Keys(r.pending)) {
+		value := r.pending[key]
+		if sent, ok := r.sent[key]; ok && sent == value {
+			continue
+		}
+		if !r.friends.SetRichPresence(key, value) {
+			return fmt.Errorf("%w: %q", ErrRichPresenceRejected, key)
+		}
+		r.sent[key] = value
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+type fakeRichPresenceFriends struct {
+	ISteamFriends
+	calls  []string
+	clears int
+}
+
+func (f *fakeRichPresenceFriends) SetRichPresence(key, value string) bool {
+	f.calls = append(f.calls, key+"="+value)
+	return true
+}
+
+func (f *fakeRichPresenceFriends) ClearRichPresence() { f.clears++ }
+
+const testRichPresenceVDF = `
+// rich presence localization
+"lang"
+{
+	"Language"	"english"
+	"Tokens"
+	{
+		"#Status_InMatch"	"In a match on {#Map_%map%}: %score%"
+		"#Status_Menu"		"In the menus"
+		"#Map_dust"			"Dust"
+		"#Quote"			"Say \"hi\""
+	}
+}
+`
+
+func TestParseRichPresenceLocalization(t *testing.T) {
+	loc, err := ParseRichPresenceLocalization([]byte(testRichPresenceVDF))
+	if err != nil {
+		t.Fatalf("ParseRichPresenceLocalization error=%v", err)
+	}
+	if got := loc.Languages(); !reflect.DeepEqual(got, []string{"english"}) {
+		t.Fatalf("Languages=%v, want [english]", got)
+	}
+	if got, ok := loc.Token("English", "#status_menu"); !ok || got != "In the menus" {
+		t.Fatalf("Token=%q,%v, want In the menus", got, ok)
+	}
+	if got, _ := loc.Token("english", "#Quote"); got != `Say "hi"` {
+		t.Fatalf("Token(#Quote)=%q", got)
+	}
+
+	combined := `"richpresence" { "english" { "tokens" { "#A" "a" } } "german" { "tokens" { "#A" "ä" } } }`
+	loc, err = ParseRichPresenceLocalization([]byte(combined))
+	if err != nil {
+		t.Fatalf("combined form error=%v", err)
+	}
+	if got := loc.Languages(); !reflect.DeepEqual(got, []string{"english", "german"}) {
+		t.Fatalf("Languages=%v, want [english german]", got)
+	}
+
+	if _, err := ParseRichPresenceLocalization([]byte(`"lang" { "Tokens" {`)); !errors.Is(err, ErrVDFSyntax) {
+		t.Fatalf("truncated error=%v, want %v", err, ErrVDFSyntax)
+	}
+	if _, err := ParseRichPresenceLocalization([]byte(`"a" "b"`)); !errors.Is(err, ErrRichPresenceLocalizationFile) {
+		t.Fatalf("tokenless error=%v, want %v", err, ErrRichPresenceLocalizationFile)
+	}
+}
+
+func TestLoadRichPresenceLocalizationUTF16(t *testing.T) {
+	units := utf16.Encode([]rune(testRichPresenceVDF))
+	data := []byte{0xff, 0xfe}
+	for _, u := range units {
+		data = append(data, byte(u), byte(u>>8))
+	}
+	path := filepath.Join(t.TempDir(), "richpresence_english.vdf")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	loc, err := LoadRichPresenceLocalization(path)
+	if err != nil {
+		t.Fatalf("LoadRichPresenceLocalization error=%v", err)
+	}
+	if _, ok := loc.Token("english", "#Map_dust"); !ok {
+		t.Fatalf("#Map_dust missing from UTF-16 file")
+	}
+}
+
+func TestRichPresenceValidate(t *testing.T) {
+	loc, err := ParseRichPresenceLocalization([]byte(testRichPresenceVDF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   error
+	}{
+		{"valid", map[string]string{"steam_display": "#Status_InMatch", "map": "dust", "score": "3-1"}, nil},
+		{"display not token", map[string]string{"steam_display": "Status_Menu"}, ErrRichPresenceDisplayNotToken},
+		{"unknown token", map[string]string{"steam_display": "#Status_Lobby"}, ErrRichPresenceUnknownToken},
+		{"missing placeholder", map[string]string{"steam_display": "#Status_InMatch", "map": "dust"}, ErrRichPresenceMissingKey},
+		{"unknown sub-token", map[string]string{"steam_display": "#Status_InMatch", "map": "nuke", "score": "0-0"}, ErrRichPresenceUnknownToken},
+		{"key too long", map[string]string{strings.Repeat("k", RichPresenceMaxKeyLength): "v"}, ErrRichPresenceKeyInvalid},
+		{"value too long", map[string]string{"status": strings.Repeat("v", RichPresenceMaxValueLength)}, ErrRichPresenceValueTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp := NewRichPresence(&fakeRichPresenceFriends{}).WithLocalization(loc)
+			for k, v := range tt.values {
+				rp.Set(k, v)
+			}
+			if err := rp.Validate(); !errors.Is(err, tt.want) {
+				t.Fatalf("Validate error=%v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	rp := NewRichPresence(&fakeRichPresenceFriends{})
+	for i := range RichPresenceMaxKeys + 1 {
+		rp.Set(string(rune('a'+i%26))+strings.Repeat("x", i/26), "v")
+	}
+	if err := rp.Validate(); !errors.Is(err, ErrRichPresenceTooManyKeys) {
+		t.Fatalf("Validate error=%v, want %v", err, ErrRichPresenceTooManyKeys)
+	}
+}
+
+func TestRichPresenceValidateTokenCycles(t *testing.T) {
+	loc, err := ParseRichPresenceLocalization([]byte(`
+"lang"
+{
+	"Language"	"english"
+	"Tokens"
+	{
+		"#Self"		"{#Self}"
+		"#A"		"a {#B}"
+		"#B"		"b {#A}"
+		"#Deep0"	"{#Deep1}"
+		"#Deep1"	"{#Deep2}"
+		"#Deep2"	"{#Deep3}"
+		"#Deep3"	"{#Deep4}"
+		"#Deep4"	"{#Deep5}"
+		"#Deep5"	"{#Deep6}"
+		"#Deep6"	"{#Deep7}"
+		"#Deep7"	"{#Deep8}"
+		"#Deep8"	"done"
+		"#Twice"	"{#Deep8} and {#Deep8}"
+	}
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for display, want := range map[string]error{
+		"#Self":  ErrRichPresenceTokenCycle,
+		"#A":     ErrRichPresenceTokenCycle,
+		"#Deep0": ErrRichPresenceTokenCycle,
+		"#Deep1": nil,
+		"#Twice": nil,
+	} {
+		rp := NewRichPresence(&fakeRichPresenceFriends{}).WithLocalization(loc).Display(display)
+		if err := rp.Validate(); !errors.Is(err, want) {
+			t.Errorf("Validate(%s) error=%v, want %v", display, err, want)
+		}
+	}
+}
+
+func TestRichPresenceCommitDiffs(t *testing.T) {
+	friends := &fakeRichPresenceFriends{}
+	rp := NewRichPresence(friends)
+
+	if err := rp.Display("#Status_InMatch").Set("map", "dust").Set("score", "0-0").Commit(); err != nil {
+		t.Fatalf("Commit error=%v", err)
+	}
+	want := []string{"map=dust", "score=0-0", "steam_display=#Status_InMatch"}
+	if !reflect.DeepEqual(friends.calls, want) {
+		t.Fatalf("calls=%v, want %v", friends.calls, want)
+	}
+
+	friends.calls = nil
+	if err := rp.Set("score", "1-0").Commit(); err != nil {
+		t.Fatalf("Commit error=%v", err)
+	}
+	if want := []string{"score=1-0"}; !reflect.DeepEqual(friends.calls, want) {
+		t.Fatalf("calls=%v, want only changed key %v", friends.calls, want)
+	}
+
+	friends.calls = nil
+	if err := rp.Delete("map").Commit(); err != nil {
+		t.Fatalf("Commit error=%v", err)
+	}
+	if want := []string{"map="}; !reflect.DeepEqual(friends.calls, want) {
+		t.Fatalf("calls=%v, want removed key cleared %v", friends.calls, want)
+	}
+
+	friends.calls = nil
+	if err := rp.Reset().Commit(); err != nil {
+		t.Fatalf("Commit error=%v", err)
+	}
+	if len(friends.calls) != 0 || friends.clears != 1 {
+		t.Fatalf("calls=%v clears=%d, want one ClearRichPresence", friends.calls, friends.clears)
+	}
+
+	friends.calls = nil
+	if err := rp.Display("not a token").Commit(); !errors.Is(err, ErrRichPresenceDisplayNotToken) {
+		t.Fatalf("Commit error=%v, want %v", err, ErrRichPresenceDisplayNotToken)
+	}
+	if len(friends.calls) != 0 {
+		t.Fatalf("invalid presence sent: %v", friends.calls)
+	}
+}
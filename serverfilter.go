@@ -0,0 +1,327 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"runtime"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// serverFilterFieldMax is the size of the key and value buffers of
+// MatchMakingKeyValuePair_t, including the NUL terminator.
+const serverFilterFieldMax = 256
+
+var (
+	ErrServerFilterInvalid = errors.New("steamworks: invalid server filter")
+	ErrServerListFailed    = errors.New("steamworks: server list request failed")
+)
+
+// matchMakingKeyValuePair mirrors MatchMakingKeyValuePair_t.
+type matchMakingKeyValuePair struct {
+	Key   [serverFilterFieldMax]byte
+	Value [serverFilterFieldMax]byte
+}
+
+type serverFilterCondition struct {
+	key, value string
+}
+
+// ServerFilter builds the filters of a server list request. Conditions are
+// combined with AND. And, Or, Nand and Nor group the conditions added by their
+// function; as Steam requires, a group's operand counts every key/value pair
+// that follows it, including those of nested groups.
+type ServerFilter struct {
+	conditions []serverFilterCondition
+	err        error
+}
+
+// NewServerFilter returns an empty filter, which matches every server.
+func NewServerFilter() *ServerFilter {
+	return &ServerFilter{}
+}
+
+// Condition adds a raw filter key and value, for keys without a dedicated
+// method.
+func (f *ServerFilter) Condition(key, value string) *ServerFilter {
+	if len(key) == 0 || len(key) >= serverFilterFieldMax || len(value) >= serverFilterFieldMax {
+		f.fail(fmt.Errorf("%w: %q=%q exceeds %d bytes", ErrServerFilterInvalid, key, value, serverFilterFieldMax-1))
+		return f
+	}
+	f.conditions = append(f.conditions, serverFilterCondition{key, value})
+	return f
+}
+
+// GameDir matches servers running the mod in dir, such as "cstrike".
+func (f *ServerFilter) GameDir(dir string) *ServerFilter {
+	return f.Condition("gamedir", dir)
+}
+
+// Map matches servers running the map name.
+func (f *ServerFilter) Map(name string) *ServerFilter {
+	return f.Condition("map", name)
+}
+
+// Secure matches VAC-secured servers.
+func (f *ServerFilter) Secure() *ServerFilter {
+	return f.Condition("secure", "1")
+}
+
+// Dedicated matches dedicated servers.
+func (f *ServerFilter) Dedicated() *ServerFilter {
+	return f.Condition("dedicated", "1")
+}
+
+// NotFull matches servers with an open player slot.
+func (f *ServerFilter) NotFull() *ServerFilter {
+	return f.Condition("notfull", "1")
+}
+
+// HasPlayers matches servers with at least one player.
+func (f *ServerFilter) HasPlayers() *ServerFilter {
+	return f.Condition("hasplayers", "1")
+}
+
+// NoPlayers matches empty servers.
+func (f *ServerFilter) NoPlayers() *ServerFilter {
+	return f.Condition("noplayers", "1")
+}
+
+// NoPassword matches servers without a password.
+func (f *ServerFilter) NoPassword() *ServerFilter {
+	return f.Condition("password", "0")
+}
+
+// AppID matches servers of appID.
+func (f *ServerFilter) AppID(appID AppId_t) *ServerFilter {
+	return f.Condition("appid", strconv.FormatUint(uint64(appID), 10))
+}
+
+// Addr matches servers at ip and, if queryPort is not zero, that query port.
+func (f *ServerFilter) Addr(ip netip.Addr, queryPort uint16) *ServerFilter {
+	if !ip.Is4() {
+		f.fail(fmt.Errorf("%w: addr %s is not IPv4", ErrServerFilterInvalid, ip))
+		return f
+	}
+	if queryPort == 0 {
+		return f.Condition("addr", ip.String())
+	}
+	return f.Condition("addr", netip.AddrPortFrom(ip, queryPort).String())
+}
+
+// GameTagsAnd matches servers with all of tags.
+func (f *ServerFilter) GameTagsAnd(tags ...string) *ServerFilter {
+	return f.tags("gametagsand", tags)
+}
+
+// GameTagsNor matches servers with none of tags.
+func (f *ServerFilter) GameTagsNor(tags ...string) *ServerFilter {
+	return f.tags("gametagsnor", tags)
+}
+
+// GameDataAnd matches servers whose game data has all of values.
+func (f *ServerFilter) GameDataAnd(values ...string) *ServerFilter {
+	return f.tags("gamedataand", values)
+}
+
+// GameDataOr matches servers whose game data has any of values.
+func (f *ServerFilter) GameDataOr(values ...string) *ServerFilter {
+	return f.tags("gamedataor", values)
+}
+
+// GameDataNor matches servers whose game data has none of values.
+func (f *ServerFilter) GameDataNor(values ...string) *ServerFilter {
+	return f.tags("gamedatanor", values)
+}
+
+// And matches servers matching every condition added by group.
+func (f *ServerFilter) And(group func(*ServerFilter)) *ServerFilter {
+	return f.group("and", group)
+}
+
+// Or matches servers matching any condition added by group.
+func (f *ServerFilter) Or(group func(*ServerFilter)) *ServerFilter {
+	return f.group("or", group)
+}
+
+// Nand matches servers not matching every condition added by group.
+func (f *ServerFilter) Nand(group func(*ServerFilter)) *ServerFilter {
+	return f.group("nand", group)
+}
+
+// Nor matches servers matching none of the conditions added by group.
+func (f *ServerFilter) Nor(group func(*ServerFilter)) *ServerFilter {
+	return f.group("nor", group)
+}
+
+// Err returns the first error found while building the filter.
+func (f *ServerFilter) Err() error {
+	return f.err
+}
+
+func (f *ServerFilter) tags(key string, tags []string) *ServerFilter {
+	if len(tags) == 0 {
+		f.fail(fmt.Errorf("%w: %s without values", ErrServerFilterInvalid, key))
+		return f
+	}
+	for _, tag := range tags {
+		if tag == "" || strings.Contains(tag, ",") {
+			f.fail(fmt.Errorf("%w: %s value %q", ErrServerFilterInvalid, key, tag))
+			return f
+		}
+	}
+	return f.Condition(key, strings.Join(tags, ","))
+}
+
+func (f *ServerFilter) group(op string, group func(*ServerFilter)) *ServerFilter {
+	sub := &ServerFilter{}
+	group(sub)
+	if sub.err != nil {
+		f.fail(sub.err)
+		return f
+	}
+	if len(sub.conditions) == 0 {
+		f.fail(fmt.Errorf("%w: empty %s group", ErrServerFilterInvalid, op))
+		return f
+	}
+	f.conditions = append(f.conditions, serverFilterCondition{op, strconv.Itoa(len(sub.conditions))})
+	f.conditions = append(f.conditions, sub.conditions...)
+	return f
+}
+
+func (f *ServerFilter) fail(err error) {
+	if f.err == nil {
+		f.err = err
+	}
+}
+
+// pinnedServerFilter is the C form of a ServerFilter: an array of pointers to
+// MatchMakingKeyValuePair_t, pinned while Steam holds it.
+type pinnedServerFilter struct {
+	pairs  []matchMakingKeyValuePair
+	ptrs   []uintptr
+	pinner runtime.Pinner
+}
+
+func (f *ServerFilter) pin() (*pinnedServerFilter, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	p := &pinnedServerFilter{}
+	if len(f.conditions) == 0 {
+		return p, nil
+	}
+	p.pairs = make([]matchMakingKeyValuePair, len(f.conditions))
+	p.ptrs = make([]uintptr, len(f.conditions))
+	p.pinner.Pin(&p.pairs[0])
+	p.pinner.Pin(&p.ptrs[0])
+	for i, c := range f.conditions {
+		copy(p.pairs[i].Key[:], c.key)
+		copy(p.pairs[i].Value[:], c.value)
+		p.ptrs[i] = uintptr(unsafe.Pointer(&p.pairs[i]))
+	}
+	return p, nil
+}
+
+func (p *pinnedServerFilter) unpin() {
+	p.pinner.Unpin()
+}
+
+// ServerListType selects which of Steam's server lists to request.
+type ServerListType int
+
+const (
+	ServerListInternet ServerListType = iota
+	ServerListLAN
+	ServerListFriends
+	ServerListFavorites
+	ServerListHistory
+	ServerListSpectator
+)
+
+// ServerList is a server list request. It owns the response object and the
+// filter memory Steam uses until Release.
+type ServerList struct {
+	servers  serverLists
+	request  HServerListRequest
+	response *ServerResponse
+	filter   *pinnedServerFilter
+}
+
+// serverLists is the part of ISteamMatchmakingServers used by ServerList.
+type serverLists interface {
+	RequestInternetServerList(appID AppId_t, filters []uintptr, response uintptr) HServerListRequest
+	RequestLANServerList(appID AppId_t, response uintptr) HServerListRequest
+	RequestFriendsServerList(appID AppId_t, filters []uintptr, response uintptr) HServerListRequest
+	RequestFavoritesServerList(appID AppId_t, filters []uintptr, response uintptr) HServerListRequest
+	RequestHistoryServerList(appID AppId_t, filters []uintptr, response uintptr) HServerListRequest
+	RequestSpectatorServerList(appID AppId_t, filters []uintptr, response uintptr) HServerListRequest
+	ReleaseRequest(request HServerListRequest)
+}
+
+// RequestServerList requests a server list of appID matching filter, which
+// may be nil, and reports results to h during RunCallbacks. LAN lists ignore
+// the filter. Call Release when done with the list.
+func RequestServerList(servers ISteamMatchmakingServers, list ServerListType, appID AppId_t, filter *ServerFilter, h ServerListHandler) (*ServerList, error) {
+	return requestServerList(servers, list, appID, filter, h)
+}
+
+func requestServerList(servers serverLists, list ServerListType, appID AppId_t, filter *ServerFilter, h ServerListHandler) (*ServerList, error) {
+	if filter == nil {
+		filter = NewServerFilter()
+	}
+	pinned, err := filter.pin()
+	if err != nil {
+		return nil, err
+	}
+	l := &ServerList{servers: servers, response: NewServerListResponse(h), filter: pinned}
+	ptr := l.response.Ptr()
+	switch list {
+	case ServerListInternet:
+		l.request = servers.RequestInternetServerList(appID, pinned.ptrs, ptr)
+	case ServerListLAN:
+		l.request = servers.RequestLANServerList(appID, ptr)
+	case ServerListFriends:
+		l.request = servers.RequestFriendsServerList(appID, pinned.ptrs, ptr)
+	case ServerListFavorites:
+		l.request = servers.RequestFavoritesServerList(appID, pinned.ptrs, ptr)
+	case ServerListHistory:
+		l.request = servers.RequestHistoryServerList(appID, pinned.ptrs, ptr)
+	case ServerListSpectator:
+		l.request = servers.RequestSpectatorServerList(appID, pinned.ptrs, ptr)
+	default:
+		l.release()
+		return nil, fmt.Errorf("%w: unknown list type %d", ErrServerListFailed, list)
+	}
+	if l.request == 0 {
+		l.release()
+		return nil, ErrServerListFailed
+	}
+	return l, nil
+}
+
+// Handle returns the request handle for ISteamMatchmakingServers methods such
+// as GetServerDetails and RefreshQuery.
+func (l *ServerList) Handle() HServerListRequest {
+	return l.request
+}
+
+// Release releases the request, then the response object and filter memory.
+// It is safe to call more than once.
+func (l *ServerList) Release() {
+	if l.request != 0 {
+		l.servers.ReleaseRequest(l.request)
+		l.request = 0
+	}
+	l.release()
+}
+
+func (l *ServerList) release() {
+	l.response.Release()
+	l.filter.unpin()
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestServerFilterNesting(t *testing.T) {
+	f := NewServerFilter().
+		AppID(440).
+		NotFull().
+		Or(func(f *ServerFilter) {
+			f.Map("cp_dustbowl").
+				And(func(f *ServerFilter) { f.Map("pl_upward").Secure() })
+		}).
+		GameTagsAnd("payload", "casual").
+		Addr(netip.MustParseAddr("10.0.0.1"), 27016)
+	if err := f.Err(); err != nil {
+		t.Fatalf("Err=%v", err)
+	}
+	want := []serverFilterCondition{
+		{"appid", "440"},
+		{"notfull", "1"},
+		{"or", "4"},
+		{"map", "cp_dustbowl"},
+		{"and", "2"},
+		{"map", "pl_upward"},
+		{"secure", "1"},
+		{"gametagsand", "payload,casual"},
+		{"addr", "10.0.0.1:27016"},
+	}
+	if !slices.Equal(f.conditions, want) {
+		t.Fatalf("conditions=%v, want %v", f.conditions, want)
+	}
+}
+
+func TestServerFilterValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *ServerFilter
+	}{
+		{"empty group", NewServerFilter().Nor(func(*ServerFilter) {})},
+		{"nested error", NewServerFilter().Or(func(f *ServerFilter) { f.GameDataOr() })},
+		{"comma in tag", NewServerFilter().GameTagsNor("a,b")},
+		{"long value", NewServerFilter().Map(strings.Repeat("m", serverFilterFieldMax))},
+		{"IPv6 addr", NewServerFilter().Addr(netip.MustParseAddr("::1"), 0)},
+	}
+	for _, tt := range tests {
+		if err := tt.filter.Err(); !errors.Is(err, ErrServerFilterInvalid) {
+			t.Errorf("%s: Err=%v, want %v", tt.name, err, ErrServerFilterInvalid)
+		}
+		if _, err := requestServerList(&fakeServerLists{}, ServerListInternet, 440, tt.filter, &recordingListHandler{}); !errors.Is(err, ErrServerFilterInvalid) {
+			t.Errorf("%s: requestServerList error=%v", tt.name, err)
+		}
+	}
+}
+
+type fakeServerLists struct {
+	serverLists
+	filters  []uintptr
+	response uintptr
+	released []HServerListRequest
+}
+
+func (f *fakeServerLists) RequestInternetServerList(_ AppId_t, filters []uintptr, response uintptr) HServerListRequest {
+	f.filters, f.response = filters, response
+	return 9
+}
+
+func (f *fakeServerLists) RequestLANServerList(AppId_t, uintptr) HServerListRequest {
+	return 0
+}
+
+func (f *fakeServerLists) ReleaseRequest(request HServerListRequest) {
+	f.released = append(f.released, request)
+}
+
+func TestRequestServerListOwnsFilterMemory(t *testing.T) {
+	servers := &fakeServerLists{}
+	l, err := requestServerList(servers, ServerListInternet, 440, NewServerFilter().GameDir("tf").Dedicated(), &recordingListHandler{})
+	if err != nil {
+		t.Fatalf("requestServerList error=%v", err)
+	}
+	if l.Handle() != 9 || servers.response != l.response.Ptr() {
+		t.Fatalf("handle=%d response=%#x, want 9 and the list's response", l.Handle(), servers.response)
+	}
+	if len(servers.filters) != 2 {
+		t.Fatalf("passed %d filter pointers, want 2", len(servers.filters))
+	}
+	for i, want := range []serverFilterCondition{{"gamedir", "tf"}, {"dedicated", "1"}} {
+		pair := &l.filter.pairs[i]
+		if servers.filters[i] != uintptr(unsafe.Pointer(pair)) {
+			t.Fatalf("filter %d does not point at its pinned pair", i)
+		}
+		if got := (serverFilterCondition{cStringToGo(pair.Key[:]), cStringToGo(pair.Value[:])}); got != want {
+			t.Fatalf("pair %d=%v, want %v", i, got, want)
+		}
+	}
+	if unsafe.Sizeof(matchMakingKeyValuePair{}) != 512 {
+		t.Fatalf("MatchMakingKeyValuePair_t size=%d, want 512", unsafe.Sizeof(matchMakingKeyValuePair{}))
+	}
+
+	l.Release()
+	l.Release()
+	if !slices.Equal(servers.released, []HServerListRequest{9}) {
+		t.Fatalf("released=%v, want [9] once", servers.released)
+	}
+	if _, ok := serverHandler[ServerListHandler](servers.response, false); ok {
+		t.Fatalf("response still registered after Release")
+	}
+
+	if _, err := requestServerList(servers, ServerListLAN, 440, nil, &recordingListHandler{}); !errors.Is(err, ErrServerListFailed) {
+		t.Fatalf("failed request error=%v, want %v", err, ErrServerListFailed)
+	}
+}
@@ -0,0 +1,368 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+var ErrServerQueryFailed = errors.New("steamworks: server query failed")
+
+// ServerListHandler receives the results of the Request*ServerList methods of
+// ISteamMatchmakingServers, like ISteamMatchmakingServerListResponse.
+type ServerListHandler interface {
+	// ServerResponded reports that server in request has details available
+	// through GetServerDetails.
+	ServerResponded(request HServerListRequest, server int)
+	ServerFailedToRespond(request HServerListRequest, server int)
+	RefreshComplete(request HServerListRequest, response EMatchMakingServerResponse)
+}
+
+// ServerPingHandler receives the result of PingServer, like
+// ISteamMatchmakingPingResponse.
+type ServerPingHandler interface {
+	// ServerResponded receives the server's details, which are only valid
+	// during the call.
+	ServerResponded(server MatchmakingServerItem)
+	ServerFailedToRespond()
+}
+
+// ServerPlayersHandler receives the results of PlayerDetails, like
+// ISteamMatchmakingPlayersResponse.
+type ServerPlayersHandler interface {
+	// AddPlayerToList is called once per player. Go cannot receive float
+	// arguments in Windows callbacks, so timePlayed is zero on Windows.
+	AddPlayerToList(name string, score int, timePlayed time.Duration)
+	PlayersFailedToRespond()
+	PlayersRefreshComplete()
+}
+
+// ServerRulesHandler receives the results of ServerRules, like
+// ISteamMatchmakingRulesResponse.
+type ServerRulesHandler interface {
+	RulesResponded(rule, value string)
+	RulesFailedToRespond()
+	RulesRefreshComplete()
+}
+
+// ServerResponse is a Go implementation of one of Steam's matchmaking server
+// response interfaces. Pass Ptr as the response argument of
+// ISteamMatchmakingServers. Handlers run on the goroutine calling
+// RunCallbacks.
+//
+// A response must stay alive until Steam stops calling it: list responses
+// until ReleaseRequest, query responses until their final callback or
+// CancelServerQuery. Query responses release themselves after their final
+// callback; call Release after ReleaseRequest or CancelServerQuery.
+type ServerResponse struct {
+	obj *cppObject
+}
+
+// cppObject has the memory layout of a C++ object with virtual methods and
+// no data members.
+type cppObject struct {
+	vtable unsafe.Pointer
+}
+
+// serverResponses maps the addresses of live response objects to their
+// handlers. Each object and its vtable stay pinned while Steam may call it.
+var serverResponses = struct {
+	sync.Mutex
+	m map[uintptr]serverResponseEntry
+}{m: make(map[uintptr]serverResponseEntry)}
+
+type serverResponseEntry struct {
+	obj     *cppObject
+	handler any
+	pinner  *runtime.Pinner
+}
+
+// NewServerListResponse wraps h as an ISteamMatchmakingServerListResponse.
+func NewServerListResponse(h ServerListHandler) *ServerResponse {
+	return newServerResponse(serverListVTable(), h)
+}
+
+// NewServerPingResponse wraps h as an ISteamMatchmakingPingResponse.
+func NewServerPingResponse(h ServerPingHandler) *ServerResponse {
+	return newServerResponse(serverPingVTable(), h)
+}
+
+// NewServerPlayersResponse wraps h as an ISteamMatchmakingPlayersResponse.
+func NewServerPlayersResponse(h ServerPlayersHandler) *ServerResponse {
+	return newServerResponse(serverPlayersVTable(), h)
+}
+
+// NewServerRulesResponse wraps h as an ISteamMatchmakingRulesResponse.
+func NewServerRulesResponse(h ServerRulesHandler) *ServerResponse {
+	return newServerResponse(serverRulesVTable(), h)
+}
+
+func newServerResponse(vtable unsafe.Pointer, handler any) *ServerResponse {
+	obj := &cppObject{vtable: vtable}
+	e := serverResponseEntry{obj: obj, handler: handler, pinner: new(runtime.Pinner)}
+	e.pinner.Pin(obj)
+	e.pinner.Pin(vtable)
+	serverResponses.Lock()
+	serverResponses.m[uintptr(unsafe.Pointer(obj))] = e
+	serverResponses.Unlock()
+	return &ServerResponse{obj: obj}
+}
+
+// Ptr returns the address of the C++ response object.
+func (r *ServerResponse) Ptr() uintptr {
+	return uintptr(unsafe.Pointer(r.obj))
+}
+
+// Release stops routing calls to the handler, unpins the response and lets it
+// be collected. It is safe to call more than once.
+func (r *ServerResponse) Release() {
+	releaseServerResponse(r.Ptr())
+}
+
+func releaseServerResponse(this uintptr) {
+	serverResponses.Lock()
+	e, ok := serverResponses.m[this]
+	delete(serverResponses.m, this)
+	serverResponses.Unlock()
+	if ok {
+		e.pinner.Unpin()
+	}
+}
+
+// serverHandler returns the handler of the response at this. final releases
+// the response, for callbacks after which Steam no longer uses it.
+func serverHandler[H any](this uintptr, final bool) (H, bool) {
+	serverResponses.Lock()
+	e, live := serverResponses.m[this]
+	if final {
+		delete(serverResponses.m, this)
+	}
+	serverResponses.Unlock()
+	if final && live {
+		e.pinner.Unpin()
+	}
+	h, ok := e.handler.(H)
+	return h, ok
+}
+
+// The vtables are built once: purego callbacks are never freed and their
+// number is limited. Callbacks return uintptr because Windows requires it.
+var (
+	serverListVTable = sync.OnceValue(func() unsafe.Pointer {
+		return unsafe.Pointer(&[3]uintptr{
+			purego.NewCallback(func(this uintptr, request HServerListRequest, server uintptr) uintptr {
+				if h, ok := serverHandler[ServerListHandler](this, false); ok {
+					h.ServerResponded(request, int(int32(server)))
+				}
+				return 0
+			}),
+			purego.NewCallback(func(this uintptr, request HServerListRequest, server uintptr) uintptr {
+				if h, ok := serverHandler[ServerListHandler](this, false); ok {
+					h.ServerFailedToRespond(request, int(int32(server)))
+				}
+				return 0
+			}),
+			purego.NewCallback(func(this uintptr, request HServerListRequest, response uintptr) uintptr {
+				if h, ok := serverHandler[ServerListHandler](this, false); ok {
+					h.RefreshComplete(request, EMatchMakingServerResponse(int32(response)))
+				}
+				return 0
+			}),
+		})
+	})
+	serverPingVTable = sync.OnceValue(func() unsafe.Pointer {
+		return unsafe.Pointer(&[2]uintptr{
+			purego.NewCallback(func(this uintptr, server *byte) uintptr {
+				if h, ok := serverHandler[ServerPingHandler](this, true); ok {
+					h.ServerResponded(MatchmakingServerItem{ptr: uintptr(unsafe.Pointer(server))})
+				}
+				return 0
+			}),
+			purego.NewCallback(func(this uintptr) uintptr {
+				if h, ok := serverHandler[ServerPingHandler](this, true); ok {
+					h.ServerFailedToRespond()
+				}
+				return 0
+			}),
+		})
+	})
+	serverPlayersVTable = sync.OnceValue(func() unsafe.Pointer {
+		return unsafe.Pointer(&[3]uintptr{
+			addPlayerToListCallback(),
+			purego.NewCallback(func(this uintptr) uintptr {
+				if h, ok := serverHandler[ServerPlayersHandler](this, true); ok {
+					h.PlayersFailedToRespond()
+				}
+				return 0
+			}),
+			purego.NewCallback(func(this uintptr) uintptr {
+				if h, ok := serverHandler[ServerPlayersHandler](this, true); ok {
+					h.PlayersRefreshComplete()
+				}
+				return 0
+			}),
+		})
+	})
+	serverRulesVTable = sync.OnceValue(func() unsafe.Pointer {
+		return unsafe.Pointer(&[3]uintptr{
+			purego.NewCallback(func(this uintptr, rule, value *byte) uintptr {
+				if h, ok := serverHandler[ServerRulesHandler](this, false); ok {
+					h.RulesResponded(cStringAt(rule), cStringAt(value))
+				}
+				return 0
+			}),
+			purego.NewCallback(func(this uintptr) uintptr {
+				if h, ok := serverHandler[ServerRulesHandler](this, true); ok {
+					h.RulesFailedToRespond()
+				}
+				return 0
+			}),
+			purego.NewCallback(func(this uintptr) uintptr {
+				if h, ok := serverHandler[ServerRulesHandler](this, true); ok {
+					h.RulesRefreshComplete()
+				}
+				return 0
+			}),
+		})
+	})
+)
+
+func addPlayerToList(this uintptr, name *byte, score uintptr, seconds float64) {
+	if h, ok := serverHandler[ServerPlayersHandler](this, false); ok {
+		h.AddPlayerToList(cStringAt(name), int(int32(score)), time.Duration(seconds*float64(time.Second)))
+	}
+}
+
+// cStringAt copies the NUL-terminated string at p.
+func cStringAt(p *byte) string {
+	if p == nil {
+		return ""
+	}
+	n := 0
+	for *(*byte)(unsafe.Add(unsafe.Pointer(p), n)) != 0 {
+		n++
+	}
+	return string(unsafe.Slice(p, n))
+}
+
+// ServerPlayer is a player reported by PlayerDetails.
+type ServerPlayer struct {
+	Name       string
+	Score      int
+	TimePlayed time.Duration
+}
+
+// serverQueries is the part of ISteamMatchmakingServers used by the query
+// helpers.
+type serverQueries interface {
+	PingServer(ip uint32, port uint16, response uintptr) HServerQuery
+	PlayerDetails(ip uint32, port uint16, response uintptr) HServerQuery
+	ServerRules(ip uint32, port uint16, response uintptr) HServerQuery
+	CancelServerQuery(query HServerQuery)
+}
+
+// PingGameServer pings the server at ip and its query port and returns its
+// details. ip is in host byte order.
+func PingGameServer(ctx context.Context, servers ISteamMatchmakingServers, ip uint32, port uint16) (GameServerItem, error) {
+	return pingGameServer(ctx, servers, ip, port)
+}
+
+// QueryServerPlayers requests the player list of the server at ip and its
+// query port and waits for it. ip is in host byte order.
+func QueryServerPlayers(ctx context.Context, servers ISteamMatchmakingServers, ip uint32, port uint16) ([]ServerPlayer, error) {
+	return queryServerPlayers(ctx, servers, ip, port)
+}
+
+// QueryServerRules requests the rules of the server at ip and its query port
+// and waits for them. ip is in host byte order.
+func QueryServerRules(ctx context.Context, servers ISteamMatchmakingServers, ip uint32, port uint16) (map[string]string, error) {
+	return queryServerRules(ctx, servers, ip, port)
+}
+
+type pingCollector struct {
+	item GameServerItem
+	done chan bool
+}
+
+func (c *pingCollector) ServerResponded(server MatchmakingServerItem) {
+	var ok bool
+	c.item, ok = server.Decode()
+	c.done <- ok
+}
+func (c *pingCollector) ServerFailedToRespond() { c.done <- false }
+
+func pingGameServer(ctx context.Context, servers serverQueries, ip uint32, port uint16) (GameServerItem, error) {
+	c := &pingCollector{done: make(chan bool, 1)}
+	r := NewServerPingResponse(c)
+	defer r.Release()
+	query := servers.PingServer(ip, port, r.Ptr())
+	if err := waitServerQuery(ctx, servers, query, c.done); err != nil {
+		return GameServerItem{}, err
+	}
+	return c.item, nil
+}
+
+type playersCollector struct {
+	players []ServerPlayer
+	done    chan bool
+}
+
+func (c *playersCollector) AddPlayerToList(name string, score int, timePlayed time.Duration) {
+	c.players = append(c.players, ServerPlayer{Name: name, Score: score, TimePlayed: timePlayed})
+}
+func (c *playersCollector) PlayersFailedToRespond() { c.done <- false }
+func (c *playersCollector) PlayersRefreshComplete() { c.done <- true }
+
+func queryServerPlayers(ctx context.Context, servers serverQueries, ip uint32, port uint16) ([]ServerPlayer, error) {
+	c := &playersCollector{done: make(chan bool, 1)}
+	r := NewServerPlayersResponse(c)
+	defer r.Release()
+	query := servers.PlayerDetails(ip, port, r.Ptr())
+	if err := waitServerQuery(ctx, servers, query, c.done); err != nil {
+		return nil, err
+	}
+	return c.players, nil
+}
+
+type rulesCollector struct {
+	rules map[string]string
+	done  chan bool
+}
+
+func (c *rulesCollector) RulesResponded(rule, value string) { c.rules[rule] = value }
+func (c *rulesCollector) RulesFailedToRespond()             { c.done <- false }
+func (c *rulesCollector) RulesRefreshComplete()             { c.done <- true }
+
+func queryServerRules(ctx context.Context, servers serverQueries, ip uint32, port uint16) (map[string]string, error) {
+	c := &rulesCollector{rules: make(map[string]string), done: make(chan bool, 1)}
+	r := NewServerRulesResponse(c)
+	defer r.Release()
+	query := servers.ServerRules(ip, port, r.Ptr())
+	if err := waitServerQuery(ctx, servers, query, c.done); err != nil {
+		return nil, err
+	}
+	return c.rules, nil
+}
+
+// waitServerQuery waits for a query's final callback and cancels the query
+// if ctx ends first. A failed query returns ErrServerQueryFailed.
+func waitServerQuery(ctx context.Context, servers serverQueries, query HServerQuery, done <-chan bool) error {
+	select {
+	case <-ctx.Done():
+		servers.CancelServerQuery(query)
+		return ctx.Err()
+	case ok := <-done:
+		if !ok {
+			return ErrServerQueryFailed
+		}
+		return nil
+	}
+}
@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+//go:build !windows
+
+package steamworks
+
+import "github.com/ebitengine/purego"
+
+func addPlayerToListCallback() uintptr {
+	return purego.NewCallback(func(this uintptr, name *byte, score uintptr, timePlayed float32) uintptr {
+		addPlayerToList(this, name, score, float64(timePlayed))
+		return 0
+	})
+}
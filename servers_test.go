@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+// responseAt returns the live response whose C++ object is at ptr.
+func responseAt(ptr uintptr) *ServerResponse {
+	serverResponses.Lock()
+	defer serverResponses.Unlock()
+	return &ServerResponse{obj: serverResponses.m[ptr].obj}
+}
+
+// vtableEntry returns the function pointer in slot i of the C++ object r.
+func vtableEntry(r *ServerResponse, i int) uintptr {
+	return unsafe.Slice((*uintptr)(r.obj.vtable), i+1)[i]
+}
+
+type recordingListHandler struct {
+	calls []string
+}
+
+func (h *recordingListHandler) ServerResponded(request HServerListRequest, server int) {
+	h.calls = append(h.calls, fmt.Sprintf("responded %d %d", request, server))
+}
+
+func (h *recordingListHandler) ServerFailedToRespond(request HServerListRequest, server int) {
+	h.calls = append(h.calls, fmt.Sprintf("failed %d %d", request, server))
+}
+
+func (h *recordingListHandler) RefreshComplete(request HServerListRequest, response EMatchMakingServerResponse) {
+	h.calls = append(h.calls, fmt.Sprintf("complete %d %d", request, response))
+}
+
+func TestServerListResponseVTable(t *testing.T) {
+	h := &recordingListHandler{}
+	r := NewServerListResponse(h)
+	defer r.Release()
+	if *(*unsafe.Pointer)(unsafe.Pointer(r.obj)) != serverListVTable() {
+		t.Fatalf("object does not start with its vtable pointer")
+	}
+
+	var responded, failed func(this uintptr, request HServerListRequest, server int32)
+	var complete func(this uintptr, request HServerListRequest, response EMatchMakingServerResponse)
+	purego.RegisterFunc(&responded, vtableEntry(r, 0))
+	purego.RegisterFunc(&failed, vtableEntry(r, 1))
+	purego.RegisterFunc(&complete, vtableEntry(r, 2))
+
+	responded(r.Ptr(), 1, 2)
+	failed(r.Ptr(), 1, 3)
+	complete(r.Ptr(), 1, EMatchMakingServerResponseNoServersListedOnMasterServer)
+	// List responses stay registered until released.
+	responded(r.Ptr(), 1, 4)
+	r.Release()
+	responded(r.Ptr(), 1, 5)
+
+	want := []string{"responded 1 2", "failed 1 3", "complete 1 2", "responded 1 4"}
+	if !slices.Equal(h.calls, want) {
+		t.Fatalf("calls=%q, want %q", h.calls, want)
+	}
+}
+
+// fakeServerQueries answers queries by calling the response's vtable the way
+// Steam would during RunCallbacks.
+type fakeServerQueries struct {
+	server    *gameServerItemData
+	players   []ServerPlayer
+	rules     [][2]string
+	fail      bool
+	cancelled []HServerQuery
+}
+
+func (f *fakeServerQueries) PingServer(_ uint32, _ uint16, response uintptr) HServerQuery {
+	r := responseAt(response)
+	var responded func(this uintptr, server *gameServerItemData)
+	var failed func(this uintptr)
+	purego.RegisterFunc(&responded, vtableEntry(r, 0))
+	purego.RegisterFunc(&failed, vtableEntry(r, 1))
+	if f.server == nil {
+		failed(response)
+	} else {
+		responded(response, f.server)
+	}
+	return 6
+}
+
+func (f *fakeServerQueries) PlayerDetails(_ uint32, _ uint16, response uintptr) HServerQuery {
+	r := responseAt(response)
+	var add func(this uintptr, name *byte, score int32, timePlayed float32)
+	var failed, complete func(this uintptr)
+	purego.RegisterFunc(&add, vtableEntry(r, 0))
+	purego.RegisterFunc(&failed, vtableEntry(r, 1))
+	purego.RegisterFunc(&complete, vtableEntry(r, 2))
+	if f.fail {
+		failed(response)
+		return 7
+	}
+	for _, p := range f.players {
+		name := append([]byte(p.Name), 0)
+		add(response, &name[0], int32(p.Score), float32(p.TimePlayed.Seconds()))
+	}
+	complete(response)
+	// Calls after the final callback are ignored.
+	complete(response)
+	return 7
+}
+
+func (f *fakeServerQueries) ServerRules(_ uint32, _ uint16, response uintptr) HServerQuery {
+	if f.rules == nil {
+		return 8
+	}
+	r := responseAt(response)
+	var rule func(this uintptr, rule, value *byte)
+	var complete func(this uintptr)
+	purego.RegisterFunc(&rule, vtableEntry(r, 0))
+	purego.RegisterFunc(&complete, vtableEntry(r, 2))
+	for _, kv := range f.rules {
+		k, v := append([]byte(kv[0]), 0), append([]byte(kv[1]), 0)
+		rule(response, &k[0], &v[0])
+	}
+	complete(response)
+	return 8
+}
+
+func (f *fakeServerQueries) CancelServerQuery(query HServerQuery) {
+	f.cancelled = append(f.cancelled, query)
+}
+
+func TestQueryServerPlayersAndRules(t *testing.T) {
+	f := &fakeServerQueries{
+		players: []ServerPlayer{{Name: "alice", Score: 12, TimePlayed: 90 * time.Second}, {Name: "bob", Score: -1}},
+		rules:   [][2]string{{"mp_timelimit", "30"}, {"sv_cheats", "0"}},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	players, err := queryServerPlayers(ctx, f, 0x7f000001, 27015)
+	if err != nil {
+		t.Fatalf("queryServerPlayers error=%v", err)
+	}
+	want := slices.Clone(f.players)
+	if runtime.GOOS == "windows" {
+		want[0].TimePlayed = 0
+	}
+	if !slices.Equal(players, want) {
+		t.Fatalf("players=%+v, want %+v", players, want)
+	}
+
+	rules, err := queryServerRules(ctx, f, 0x7f000001, 27015)
+	if err != nil || !maps.Equal(rules, map[string]string{"mp_timelimit": "30", "sv_cheats": "0"}) {
+		t.Fatalf("rules=%v, %v", rules, err)
+	}
+
+	f.fail = true
+	if _, err := queryServerPlayers(ctx, f, 0x7f000001, 27015); !errors.Is(err, ErrServerQueryFailed) {
+		t.Fatalf("failed query error=%v, want %v", err, ErrServerQueryFailed)
+	}
+
+	serverResponses.Lock()
+	live := len(serverResponses.m)
+	serverResponses.Unlock()
+	if live != 0 {
+		t.Fatalf("%d responses still registered", live)
+	}
+}
+
+func TestQueryServerRulesCancelsOnContext(t *testing.T) {
+	f := &fakeServerQueries{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := queryServerRules(ctx, f, 0x7f000001, 27015); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error=%v, want %v", err, context.DeadlineExceeded)
+	}
+	if !slices.Equal(f.cancelled, []HServerQuery{8}) {
+		t.Fatalf("cancelled=%v, want [8]", f.cancelled)
+	}
+}
@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import "github.com/ebitengine/purego"
+
+// Windows callbacks cannot receive float arguments, so the time played, passed
+// in a floating-point register, is reported as zero.
+func addPlayerToListCallback() uintptr {
+	return purego.NewCallback(func(this uintptr, name *byte, score uintptr, _ uintptr) uintptr {
+		addPlayerToList(this, name, score, 0)
+		return 0
+	})
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"sync"
+)
+
+// GameServerQueryPortShared mirrors STEAMGAMESERVER_QUERY_PORT_SHARED. Pass it
+// as the query port to InitGameServer to serve queries on the game port
+// through a SharedPacketConn.
+const GameServerQueryPortShared uint16 = 0xFFFF
+
+// gameServerPacketMax is the size of the buffer outgoing Steam packets are
+// drained into.
+const gameServerPacketMax = 16 * 1024
+
+// steamPacketPrefix starts every server query and master server packet.
+var steamPacketPrefix = []byte{0xFF, 0xFF, 0xFF, 0xFF}
+
+// gameServerPackets is the part of ISteamGameServer used by SharedPacketConn.
+type gameServerPackets interface {
+	HandleIncomingPacket(data []byte, ip uint32, port uint16) bool
+	GetNextOutgoingPacket(dest []byte) (size int32, ip uint32, port uint16)
+}
+
+// SharedPacketConn shares a game server's UDP socket with Steam. ReadFrom
+// hands Steam's query and master server packets to HandleIncomingPacket and
+// returns only the game's own datagrams; Flush sends the packets Steam has
+// queued. Other methods go straight to the wrapped connection.
+type SharedPacketConn struct {
+	net.PacketConn
+	server gameServerPackets
+
+	// mu serializes calls into Steam from ReadFrom and Flush.
+	mu  sync.Mutex
+	out []byte
+}
+
+// NewSharedPacketConn wraps conn, the socket bound to the game port, for a
+// game server initialized with GameServerQueryPortShared.
+func NewSharedPacketConn(conn net.PacketConn, server ISteamGameServer) *SharedPacketConn {
+	return newSharedPacketConn(conn, server)
+}
+
+func newSharedPacketConn(conn net.PacketConn, server gameServerPackets) *SharedPacketConn {
+	return &SharedPacketConn{PacketConn: conn, server: server}
+}
+
+// ReadFrom reads the next datagram that is not for Steam. Datagrams starting
+// with 0xFFFFFFFF are passed to HandleIncomingPacket; Steam only accepts them
+// from IPv4 senders and the rest are dropped.
+func (c *SharedPacketConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	for {
+		n, addr, err := c.PacketConn.ReadFrom(p)
+		if err != nil || n < len(steamPacketPrefix) || !bytes.HasPrefix(p[:n], steamPacketPrefix) {
+			return n, addr, err
+		}
+		c.handle(p[:n], addr)
+	}
+}
+
+func (c *SharedPacketConn) handle(packet []byte, addr net.Addr) {
+	udp, ok := addr.(*net.UDPAddr)
+	if !ok {
+		return
+	}
+	from := udp.AddrPort()
+	ip := from.Addr().Unmap()
+	if !ip.Is4() {
+		return
+	}
+	ip4 := ip.As4()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.server.HandleIncomingPacket(packet, binary.BigEndian.Uint32(ip4[:]), from.Port())
+}
+
+// Flush sends every packet Steam has queued for the socket. Call it each
+// tick, after RunCallbacks. It stops at the first write error; the packet
+// that failed is lost and the rest stay queued.
+func (c *SharedPacketConn) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.out == nil {
+		c.out = make([]byte, gameServerPacketMax)
+	}
+	for {
+		size, ip, port := c.server.GetNextOutgoingPacket(c.out)
+		if size <= 0 {
+			return nil
+		}
+		to := ServerNetAddr{IP: ip, QueryPort: port}.QueryAddr()
+		if _, err := c.PacketConn.WriteTo(c.out[:size], net.UDPAddrFromAddrPort(to)); err != nil {
+			return err
+		}
+	}
+}
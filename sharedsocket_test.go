@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"bytes"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeIncomingPacket struct {
+	data []byte
+	ip   uint32
+	port uint16
+}
+
+type fakeGameServerPackets struct {
+	mu       sync.Mutex
+	incoming []fakeIncomingPacket
+	outgoing []fakeIncomingPacket
+}
+
+func (f *fakeGameServerPackets) HandleIncomingPacket(data []byte, ip uint32, port uint16) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.incoming = append(f.incoming, fakeIncomingPacket{bytes.Clone(data), ip, port})
+	return true
+}
+
+func (f *fakeGameServerPackets) GetNextOutgoingPacket(dest []byte) (int32, uint32, uint16) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.outgoing) == 0 {
+		return 0, 0, 0
+	}
+	p := f.outgoing[0]
+	f.outgoing = f.outgoing[1:]
+	return int32(copy(dest, p.data)), p.ip, p.port
+}
+
+func listenUDP(t *testing.T) net.PacketConn {
+	t.Helper()
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func TestSharedPacketConnRoutesSteamPackets(t *testing.T) {
+	server := &fakeGameServerPackets{}
+	conn := newSharedPacketConn(listenUDP(t), server)
+	peer := listenUDP(t)
+	peerPort := uint16(peer.LocalAddr().(*net.UDPAddr).Port)
+
+	query := []byte("\xFF\xFF\xFF\xFFTSource Engine Query\x00")
+	for _, packet := range [][]byte{query, []byte("game"), []byte("\xFF\xFF")} {
+		if _, err := peer.WriteTo(packet, conn.LocalAddr()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	buf := make([]byte, 1500)
+	for _, want := range []string{"game", "\xFF\xFF"} {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(buf[:n]) != want || from.String() != peer.LocalAddr().String() {
+			t.Errorf("ReadFrom = %q from %v, want %q from %v", buf[:n], from, want, peer.LocalAddr())
+		}
+	}
+
+	server.mu.Lock()
+	incoming := server.incoming
+	server.mu.Unlock()
+	if len(incoming) != 1 {
+		t.Fatalf("HandleIncomingPacket called %d times, want 1", len(incoming))
+	}
+	if got := incoming[0]; !bytes.Equal(got.data, query) || got.ip != 0x7F000001 || got.port != peerPort {
+		t.Errorf("incoming = %q %#x:%d, want %q 0x7f000001:%d", got.data, got.ip, got.port, query, peerPort)
+	}
+}
+
+func TestSharedPacketConnFlush(t *testing.T) {
+	peer := listenUDP(t)
+	peerPort := uint16(peer.LocalAddr().(*net.UDPAddr).Port)
+	server := &fakeGameServerPackets{outgoing: []fakeIncomingPacket{
+		{[]byte("\xFF\xFF\xFF\xFFIfirst"), 0x7F000001, peerPort},
+		{[]byte("\xFF\xFF\xFF\xFFIsecond"), 0x7F000001, peerPort},
+	}}
+	conn := newSharedPacketConn(listenUDP(t), server)
+
+	if err := conn.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	buf := make([]byte, 1500)
+	for _, want := range []string{"\xFF\xFF\xFF\xFFIfirst", "\xFF\xFF\xFF\xFFIsecond"} {
+		n, from, err := peer.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(buf[:n]) != want || from.String() != conn.LocalAddr().String() {
+			t.Errorf("peer got %q from %v, want %q from %v", buf[:n], from, want, conn.LocalAddr())
+		}
+	}
+	if len(server.outgoing) != 0 {
+		t.Errorf("%d packets left queued", len(server.outgoing))
+	}
+}
@@ -28,6 +28,7 @@ type HSteamUser int32
 type HAuthTicket uint32
 type HServerListRequest uintptr
 type HServerQuery int32
+type FriendsGroupID_t int16
 
 type HSteamNetConnection uint32
 type HSteamListenSocket uint32
@@ -270,11 +271,30 @@ const (
 	EChatEntryTypeLinkBlocked      EChatEntryType = 14
 )
 
+// EChatMemberStateChange mirrors Steam's EChatMemberStateChange flags reported by LobbyChatUpdate_t.
+type EChatMemberStateChange uint32
+
+const (
+	EChatMemberStateChangeEntered      EChatMemberStateChange = 0x0001
+	EChatMemberStateChangeLeft         EChatMemberStateChange = 0x0002
+	EChatMemberStateChangeDisconnected EChatMemberStateChange = 0x0004
+	EChatMemberStateChangeKicked       EChatMemberStateChange = 0x0008
+	EChatMemberStateChangeBanned       EChatMemberStateChange = 0x0010
+)
+
+// Has reports whether all bits in flag are set.
+func (c EChatMemberStateChange) Has(flag EChatMemberStateChange) bool {
+	return c&flag == flag
+}
+
 // Steam matchmaking callback IDs for lobby events.
 const (
+	CallbackIDLobbyEnter      CallbackID = 504
 	CallbackIDLobbyDataUpdate CallbackID = 505
 	CallbackIDLobbyChatUpdate CallbackID = 506
 	CallbackIDLobbyChatMsg    CallbackID = 507
+	CallbackIDLobbyMatchList  CallbackID = 510
+	CallbackIDLobbyCreated    CallbackID = 513
 
 	// CallbackIDSteamRemotePlaySessionAvatarLoaded mirrors SteamRemotePlaySessionAvatarLoaded_t::k_iCallback.
 	CallbackIDSteamRemotePlaySessionAvatarLoaded CallbackID = 5704
@@ -288,6 +308,219 @@ type SteamRemotePlaySessionAvatarLoaded struct {
 	Height    int32
 }
 
+// Steam friends callback IDs.
+const (
+	CallbackIDPersonaStateChange               CallbackID = 304
+	CallbackIDGameOverlayActivated             CallbackID = 331
+	CallbackIDGameLobbyJoinRequested           CallbackID = 333
+	CallbackIDAvatarImageLoaded                CallbackID = 334
+	CallbackIDClanOfficerListResponse          CallbackID = 335
+	CallbackIDFriendRichPresenceUpdate         CallbackID = 336
+	CallbackIDGameRichPresenceJoinRequested    CallbackID = 337
+	CallbackIDGameConnectedClanChatMsg         CallbackID = 338
+	CallbackIDGameConnectedChatJoin            CallbackID = 339
+	CallbackIDGameConnectedChatLeave           CallbackID = 340
+	CallbackIDDownloadClanActivityCountsResult CallbackID = 341
+	CallbackIDJoinClanChatRoomCompletionResult CallbackID = 342
+	CallbackIDOverlayBrowserProtocolNavigation CallbackID = 349
+)
+
+// EPersonaChange mirrors Steam's EPersonaChange flags reported by PersonaStateChange_t.
+type EPersonaChange int32
+
+const (
+	EPersonaChangeName                EPersonaChange = 0x0001
+	EPersonaChangeStatus              EPersonaChange = 0x0002
+	EPersonaChangeComeOnline          EPersonaChange = 0x0004
+	EPersonaChangeGoneOffline         EPersonaChange = 0x0008
+	EPersonaChangeGamePlayed          EPersonaChange = 0x0010
+	EPersonaChangeGameServer          EPersonaChange = 0x0020
+	EPersonaChangeAvatar              EPersonaChange = 0x0040
+	EPersonaChangeJoinedSource        EPersonaChange = 0x0080
+	EPersonaChangeLeftSource          EPersonaChange = 0x0100
+	EPersonaChangeRelationshipChanged EPersonaChange = 0x0200
+	EPersonaChangeNameFirstSet        EPersonaChange = 0x0400
+	EPersonaChangeBroadcast           EPersonaChange = 0x0800
+	EPersonaChangeNickname            EPersonaChange = 0x1000
+	EPersonaChangeSteamLevel          EPersonaChange = 0x2000
+	EPersonaChangeRichPresence        EPersonaChange = 0x4000
+)
+
+// Has reports whether all bits in flag are set.
+func (c EPersonaChange) Has(flag EPersonaChange) bool {
+	return c&flag == flag
+}
+
+// PersonaStateChange mirrors Steam's PersonaStateChange_t callback payload.
+type PersonaStateChange struct {
+	SteamID     CSteamID
+	ChangeFlags EPersonaChange
+}
+
+// AvatarImageLoaded mirrors Steam's AvatarImageLoaded_t callback payload.
+type AvatarImageLoaded struct {
+	SteamID CSteamID
+	Image   int32
+	Wide    int32
+	Tall    int32
+}
+
+// FriendsGroupIDInvalid mirrors k_FriendsGroupID_Invalid.
+const FriendsGroupIDInvalid FriendsGroupID_t = -1
+
+// FriendRichPresenceUpdate mirrors Steam's FriendRichPresenceUpdate_t callback payload.
+type FriendRichPresenceUpdate struct {
+	SteamIDFriend CSteamID
+	AppID         AppId_t
+}
+
+// EMatchMakingServerResponse mirrors Steam's EMatchMakingServerResponse.
+type EMatchMakingServerResponse int32
+
+const (
+	EMatchMakingServerResponseServerResponded               EMatchMakingServerResponse = 0
+	EMatchMakingServerResponseServerFailedToRespond         EMatchMakingServerResponse = 1
+	EMatchMakingServerResponseNoServersListedOnMasterServer EMatchMakingServerResponse = 2
+)
+
+// EChatRoomEnterResponse mirrors Steam's EChatRoomEnterResponse.
+type EChatRoomEnterResponse int32
+
+const (
+	EChatRoomEnterResponseSuccess           EChatRoomEnterResponse = 1
+	EChatRoomEnterResponseDoesntExist       EChatRoomEnterResponse = 2
+	EChatRoomEnterResponseNotAllowed        EChatRoomEnterResponse = 3
+	EChatRoomEnterResponseFull              EChatRoomEnterResponse = 4
+	EChatRoomEnterResponseError             EChatRoomEnterResponse = 5
+	EChatRoomEnterResponseBanned            EChatRoomEnterResponse = 6
+	EChatRoomEnterResponseLimited           EChatRoomEnterResponse = 7
+	EChatRoomEnterResponseClanDisabled      EChatRoomEnterResponse = 8
+	EChatRoomEnterResponseCommunityBan      EChatRoomEnterResponse = 9
+	EChatRoomEnterResponseMemberBlockedYou  EChatRoomEnterResponse = 10
+	EChatRoomEnterResponseYouBlockedMember  EChatRoomEnterResponse = 11
+	EChatRoomEnterResponseRatelimitExceeded EChatRoomEnterResponse = 15
+)
+
+// ClanOfficerListResponse mirrors Steam's ClanOfficerListResponse_t call result payload.
+type ClanOfficerListResponse struct {
+	SteamIDClan CSteamID
+	Officers    int32
+	Success     uint8
+}
+
+// DownloadClanActivityCountsResult mirrors Steam's DownloadClanActivityCountsResult_t call result payload.
+type DownloadClanActivityCountsResult struct {
+	Success bool
+}
+
+// JoinClanChatRoomCompletionResult mirrors Steam's JoinClanChatRoomCompletionResult_t call result payload.
+type JoinClanChatRoomCompletionResult struct {
+	SteamIDClanChat       CSteamID
+	ChatRoomEnterResponse EChatRoomEnterResponse
+}
+
+// GameConnectedClanChatMsg mirrors Steam's GameConnectedClanChatMsg_t callback payload.
+type GameConnectedClanChatMsg struct {
+	SteamIDClanChat CSteamID
+	SteamIDUser     CSteamID
+	MessageID       int32
+}
+
+// GameConnectedChatJoin mirrors Steam's GameConnectedChatJoin_t callback payload.
+type GameConnectedChatJoin struct {
+	SteamIDClanChat CSteamID
+	SteamIDUser     CSteamID
+}
+
+// GameConnectedChatLeave mirrors Steam's GameConnectedChatLeave_t callback payload.
+type GameConnectedChatLeave struct {
+	SteamIDClanChat CSteamID
+	SteamIDUser     CSteamID
+	Kicked          bool
+	Dropped         bool
+}
+
+// GameOverlayActivated mirrors Steam's GameOverlayActivated_t callback payload.
+type GameOverlayActivated struct {
+	Active        uint8
+	UserInitiated bool
+	_             [2]byte
+	AppID         AppId_t
+	OverlayPID    uint32
+}
+
+// OverlayBrowserProtocolNavigation mirrors Steam's OverlayBrowserProtocolNavigation_t callback payload.
+type OverlayBrowserProtocolNavigation struct {
+	URI [1024]byte
+}
+
+// URIString returns the navigated URI as a Go string.
+func (n OverlayBrowserProtocolNavigation) URIString() string {
+	return cStringToGo(n.URI[:])
+}
+
+// GameLobbyJoinRequested mirrors Steam's GameLobbyJoinRequested_t callback payload.
+type GameLobbyJoinRequested struct {
+	SteamIDLobby  CSteamID
+	SteamIDFriend CSteamID
+}
+
+// GameRichPresenceJoinRequested mirrors Steam's GameRichPresenceJoinRequested_t callback payload.
+type GameRichPresenceJoinRequested struct {
+	SteamIDFriend CSteamID
+	Connect       [256]byte
+}
+
+// ConnectString returns the friend's "connect" rich presence value as a Go string.
+func (r GameRichPresenceJoinRequested) ConnectString() string {
+	return cStringToGo(r.Connect[:])
+}
+
+// Steam apps callback IDs.
+const (
+	CallbackIDDlcInstalled                  CallbackID = 1005
+	CallbackIDNewUrlLaunchParameters        CallbackID = 1014
+	CallbackIDAppProofOfPurchaseKeyResponse CallbackID = 1021
+	CallbackIDFileDetailsResult             CallbackID = 1023
+)
+
+// DlcInstalled mirrors Steam's DlcInstalled_t callback payload.
+type DlcInstalled struct {
+	AppID AppId_t
+}
+
+// NewUrlLaunchParameters mirrors Steam's NewUrlLaunchParameters_t callback payload, which carries no data.
+type NewUrlLaunchParameters struct {
+	_ uint8
+}
+
+// AppProofOfPurchaseKeyResponse mirrors Steam's AppProofOfPurchaseKeyResponse_t callback payload.
+type AppProofOfPurchaseKeyResponse struct {
+	Result    EResult
+	AppID     uint32
+	KeyLength uint32
+	Key       [240]byte
+}
+
+// KeyString returns the proof-of-purchase key as a Go string.
+func (r AppProofOfPurchaseKeyResponse) KeyString() string {
+	return cStringToGo(r.Key[:])
+}
+
+// LobbyEnter mirrors Steam's LobbyEnter_t callback payload.
+type LobbyEnter struct {
+	SteamIDLobby          CSteamID
+	ChatPermissions       uint32
+	Locked                bool
+	_                     [3]byte
+	ChatRoomEnterResponse EChatRoomEnterResponse
+}
+
+// LobbyMatchList mirrors Steam's LobbyMatchList_t call result payload.
+type LobbyMatchList struct {
+	LobbiesMatching uint32
+}
+
 // LobbyDataUpdate mirrors Steam's LobbyDataUpdate_t callback payload.
 type LobbyDataUpdate struct {
 	LobbySteamID  CSteamID
@@ -303,6 +536,11 @@ type LobbyChatUpdate struct {
 	ChatMemberStateChange uint32
 }
 
+// StateChange returns the callback's member state change as EChatMemberStateChange.
+func (u LobbyChatUpdate) StateChange() EChatMemberStateChange {
+	return EChatMemberStateChange(u.ChatMemberStateChange)
+}
+
 // LobbyChatMsg mirrors Steam's LobbyChatMsg_t callback payload.
 type LobbyChatMsg struct {
 	LobbySteamID  CSteamID
@@ -320,15 +558,39 @@ func (m LobbyChatMsg) EntryType() EChatEntryType {
 type EResult int32
 
 const (
-	EResultNone         EResult = 0
-	EResultOK           EResult = 1
-	EResultFail         EResult = 2
-	EResultNoConnection EResult = 3
-	EResultInvalidParam EResult = 8
-	EResultBusy         EResult = 10
-	EResultTimeout      EResult = 16
+	EResultNone               EResult = 0
+	EResultOK                 EResult = 1
+	EResultFail               EResult = 2
+	EResultNoConnection       EResult = 3
+	EResultInvalidParam       EResult = 8
+	EResultBusy               EResult = 10
+	EResultAccessDenied       EResult = 15
+	EResultTimeout            EResult = 16
+	EResultBanned             EResult = 17
+	EResultInvalidSteamID     EResult = 19
+	EResultServiceUnavailable EResult = 20
+	EResultLimitExceeded      EResult = 25
+	EResultExpired            EResult = 27
+	EResultRateLimitExceeded  EResult = 84
 )
 
+// EBetaBranchFlags mirrors Steam's EBetaBranchFlags bitset reported by GetBetaInfo.
+type EBetaBranchFlags uint32
+
+const (
+	EBetaBranchNone      EBetaBranchFlags = 0
+	EBetaBranchDefault   EBetaBranchFlags = 1 << 0
+	EBetaBranchAvailable EBetaBranchFlags = 1 << 1
+	EBetaBranchPrivate   EBetaBranchFlags = 1 << 2
+	EBetaBranchSelected  EBetaBranchFlags = 1 << 3
+	EBetaBranchInstalled EBetaBranchFlags = 1 << 4
+)
+
+// Has reports whether all bits in flag are set.
+func (f EBetaBranchFlags) Has(flag EBetaBranchFlags) bool {
+	return f&flag == flag
+}
+
 type SteamNetworkingSendFlags int32
 
 const (
@@ -629,6 +891,7 @@ type ISteamApps interface {
 	GetLaunchCommandLine(bufferSize int) string
 	GetNumBetas() (total int, available int, private int)
 	GetBetaInfo(index int) (flags uint32, buildID uint32, lastUpdated uint32, name string, description string, ok bool)
+	Betas() iter.Seq[Beta]
 	InstallDLC(appID AppId_t)
 	UninstallDLC(appID AppId_t)
 	RequestAppProofOfPurchaseKey(appID AppId_t)
@@ -834,9 +1097,48 @@ type ISteamFriends interface {
 	GetSmallFriendAvatar(friend CSteamID) int32
 	GetMediumFriendAvatar(friend CSteamID) int32
 	GetLargeFriendAvatar(friend CSteamID) int32
+	RequestUserInformation(user CSteamID, requireNameOnly bool) bool
 	SetRichPresence(string, string) bool
+	ClearRichPresence()
+	GetFriendRichPresence(friend CSteamID, key string) string
+	GetFriendRichPresenceKeyCount(friend CSteamID) int
+	GetFriendRichPresenceKeyByIndex(friend CSteamID, index int) string
+	FriendRichPresence(friend CSteamID) iter.Seq2[string, string]
+	RequestFriendRichPresence(friend CSteamID)
+	GetFriendsGroupCount() int
+	GetFriendsGroupIDByIndex(index int) FriendsGroupID_t
+	FriendsGroups() iter.Seq[FriendsGroupID_t]
+	GetFriendsGroupName(groupID FriendsGroupID_t) string
+	GetFriendsGroupMembersCount(groupID FriendsGroupID_t) int
+	GetFriendsGroupMembersList(groupID FriendsGroupID_t) []CSteamID
+	FriendsGroupMembers(groupID FriendsGroupID_t) iter.Seq[CSteamID]
 	GetFriendGamePlayed(friend CSteamID) (FriendGameInfo, bool)
 	InviteUserToGame(friend CSteamID, connectString string) bool
+	GetClanCount() int
+	GetClanByIndex(index int) CSteamID
+	Clans() iter.Seq[CSteamID]
+	GetClanName(clanID CSteamID) string
+	GetClanTag(clanID CSteamID) string
+	GetClanActivityCounts(clanID CSteamID) (online, inGame, chatting int, ok bool)
+	DownloadClanActivityCounts(clanIDs []CSteamID) SteamAPICall_t
+	GetClanOwner(clanID CSteamID) CSteamID
+	RequestClanOfficerList(clanID CSteamID) SteamAPICall_t
+	GetClanOfficerCount(clanID CSteamID) int
+	GetClanOfficerByIndex(clanID CSteamID, index int) CSteamID
+	ClanOfficers(clanID CSteamID) iter.Seq[CSteamID]
+	IsClanPublic(clanID CSteamID) bool
+	IsClanOfficialGameGroup(clanID CSteamID) bool
+	JoinClanChatRoom(clanID CSteamID) SteamAPICall_t
+	LeaveClanChatRoom(clanChatID CSteamID) bool
+	GetClanChatMemberCount(clanChatID CSteamID) int
+	GetChatMemberByIndex(clanChatID CSteamID, index int) CSteamID
+	ClanChatMembers(clanChatID CSteamID) iter.Seq[CSteamID]
+	SendClanChatMessage(clanChatID CSteamID, text string) bool
+	GetClanChatMessage(clanChatID CSteamID, messageID int, text []byte) (bytesCopied int, entryType EChatEntryType, chatter CSteamID)
+	IsClanChatAdmin(clanChatID, user CSteamID) bool
+	IsClanChatWindowOpenInSteam(clanChatID CSteamID) bool
+	OpenClanChatWindowInSteam(clanChatID CSteamID) bool
+	CloseClanChatWindowInSteam(clanChatID CSteamID) bool
 	ActivateGameOverlay(dialog string)
 	ActivateGameOverlayToUser(dialog string, steamID CSteamID)
 	ActivateGameOverlayToWebPage(url string, mode EActivateGameOverlayToWebPageMode)
@@ -1072,6 +1374,39 @@ const (
 	flatAPI_ISteamFriends_ActivateGameOverlayToStore                   = "SteamAPI_ISteamFriends_ActivateGameOverlayToStore"
 	flatAPI_ISteamFriends_ActivateGameOverlayInviteDialog              = "SteamAPI_ISteamFriends_ActivateGameOverlayInviteDialog"
 	flatAPI_ISteamFriends_ActivateGameOverlayInviteDialogConnectString = "SteamAPI_ISteamFriends_ActivateGameOverlayInviteDialogConnectString"
+	flatAPI_ISteamFriends_ClearRichPresence                            = "SteamAPI_ISteamFriends_ClearRichPresence"
+	flatAPI_ISteamFriends_GetFriendRichPresence                        = "SteamAPI_ISteamFriends_GetFriendRichPresence"
+	flatAPI_ISteamFriends_GetFriendRichPresenceKeyCount                = "SteamAPI_ISteamFriends_GetFriendRichPresenceKeyCount"
+	flatAPI_ISteamFriends_GetFriendRichPresenceKeyByIndex              = "SteamAPI_ISteamFriends_GetFriendRichPresenceKeyByIndex"
+	flatAPI_ISteamFriends_RequestFriendRichPresence                    = "SteamAPI_ISteamFriends_RequestFriendRichPresence"
+	flatAPI_ISteamFriends_GetFriendsGroupCount                         = "SteamAPI_ISteamFriends_GetFriendsGroupCount"
+	flatAPI_ISteamFriends_GetFriendsGroupIDByIndex                     = "SteamAPI_ISteamFriends_GetFriendsGroupIDByIndex"
+	flatAPI_ISteamFriends_GetFriendsGroupName                          = "SteamAPI_ISteamFriends_GetFriendsGroupName"
+	flatAPI_ISteamFriends_GetFriendsGroupMembersCount                  = "SteamAPI_ISteamFriends_GetFriendsGroupMembersCount"
+	flatAPI_ISteamFriends_GetFriendsGroupMembersList                   = "SteamAPI_ISteamFriends_GetFriendsGroupMembersList"
+	flatAPI_ISteamFriends_RequestUserInformation                       = "SteamAPI_ISteamFriends_RequestUserInformation"
+	flatAPI_ISteamFriends_GetClanCount                                 = "SteamAPI_ISteamFriends_GetClanCount"
+	flatAPI_ISteamFriends_GetClanByIndex                               = "SteamAPI_ISteamFriends_GetClanByIndex"
+	flatAPI_ISteamFriends_GetClanName                                  = "SteamAPI_ISteamFriends_GetClanName"
+	flatAPI_ISteamFriends_GetClanTag                                   = "SteamAPI_ISteamFriends_GetClanTag"
+	flatAPI_ISteamFriends_GetClanActivityCounts                        = "SteamAPI_ISteamFriends_GetClanActivityCounts"
+	flatAPI_ISteamFriends_DownloadClanActivityCounts                   = "SteamAPI_ISteamFriends_DownloadClanActivityCounts"
+	flatAPI_ISteamFriends_GetClanOwner                                 = "SteamAPI_ISteamFriends_GetClanOwner"
+	flatAPI_ISteamFriends_GetClanOfficerCount                          = "SteamAPI_ISteamFriends_GetClanOfficerCount"
+	flatAPI_ISteamFriends_GetClanOfficerByIndex                        = "SteamAPI_ISteamFriends_GetClanOfficerByIndex"
+	flatAPI_ISteamFriends_RequestClanOfficerList                       = "SteamAPI_ISteamFriends_RequestClanOfficerList"
+	flatAPI_ISteamFriends_IsClanPublic                                 = "SteamAPI_ISteamFriends_IsClanPublic"
+	flatAPI_ISteamFriends_IsClanOfficialGameGroup                      = "SteamAPI_ISteamFriends_IsClanOfficialGameGroup"
+	flatAPI_ISteamFriends_JoinClanChatRoom                             = "SteamAPI_ISteamFriends_JoinClanChatRoom"
+	flatAPI_ISteamFriends_LeaveClanChatRoom                            = "SteamAPI_ISteamFriends_LeaveClanChatRoom"
+	flatAPI_ISteamFriends_GetClanChatMemberCount                       = "SteamAPI_ISteamFriends_GetClanChatMemberCount"
+	flatAPI_ISteamFriends_GetChatMemberByIndex                         = "SteamAPI_ISteamFriends_GetChatMemberByIndex"
+	flatAPI_ISteamFriends_SendClanChatMessage                          = "SteamAPI_ISteamFriends_SendClanChatMessage"
+	flatAPI_ISteamFriends_GetClanChatMessage                           = "SteamAPI_ISteamFriends_GetClanChatMessage"
+	flatAPI_ISteamFriends_IsClanChatAdmin                              = "SteamAPI_ISteamFriends_IsClanChatAdmin"
+	flatAPI_ISteamFriends_IsClanChatWindowOpenInSteam                  = "SteamAPI_ISteamFriends_IsClanChatWindowOpenInSteam"
+	flatAPI_ISteamFriends_OpenClanChatWindowInSteam                    = "SteamAPI_ISteamFriends_OpenClanChatWindowInSteam"
+	flatAPI_ISteamFriends_CloseClanChatWindowInSteam                   = "SteamAPI_ISteamFriends_CloseClanChatWindowInSteam"
 
 	flatAPI_SteamMatchmaking                                             = "SteamAPI_SteamMatchmaking_v009"
 	flatAPI_ISteamMatchmaking_GetFavoriteGameCount                       = "SteamAPI_ISteamMatchmaking_GetFavoriteGameCount"
@@ -787,6 +787,39 @@ func allRegisteredFunctions() []registeredFunction {
 		{name: "ptrAPI_ISteamFriends_ActivateGameOverlayToStore", value: ptrAPI_ISteamFriends_ActivateGameOverlayToStore},
 		{name: "ptrAPI_ISteamFriends_ActivateGameOverlayInviteDialog", value: ptrAPI_ISteamFriends_ActivateGameOverlayInviteDialog},
 		{name: "ptrAPI_ISteamFriends_ActivateGameOverlayInviteDialogConnectString", value: ptrAPI_ISteamFriends_ActivateGameOverlayInviteDialogConnectString},
+		{name: "ptrAPI_ISteamFriends_ClearRichPresence", value: ptrAPI_ISteamFriends_ClearRichPresence},
+		{name: "ptrAPI_ISteamFriends_GetFriendRichPresence", value: ptrAPI_ISteamFriends_GetFriendRichPresence},
+		{name: "ptrAPI_ISteamFriends_GetFriendRichPresenceKeyCount", value: ptrAPI_ISteamFriends_GetFriendRichPresenceKeyCount},
+		{name: "ptrAPI_ISteamFriends_GetFriendRichPresenceKeyByIndex", value: ptrAPI_ISteamFriends_GetFriendRichPresenceKeyByIndex},
+		{name: "ptrAPI_ISteamFriends_RequestFriendRichPresence", value: ptrAPI_ISteamFriends_RequestFriendRichPresence},
+		{name: "ptrAPI_ISteamFriends_GetFriendsGroupCount", value: ptrAPI_ISteamFriends_GetFriendsGroupCount},
+		{name: "ptrAPI_ISteamFriends_GetFriendsGroupIDByIndex", value: ptrAPI_ISteamFriends_GetFriendsGroupIDByIndex},
+		{name: "ptrAPI_ISteamFriends_GetFriendsGroupName", value: ptrAPI_ISteamFriends_GetFriendsGroupName},
+		{name: "ptrAPI_ISteamFriends_GetFriendsGroupMembersCount", value: ptrAPI_ISteamFriends_GetFriendsGroupMembersCount},
+		{name: "ptrAPI_ISteamFriends_GetFriendsGroupMembersList", value: ptrAPI_ISteamFriends_GetFriendsGroupMembersList},
+		{name: "ptrAPI_ISteamFriends_RequestUserInformation", value: ptrAPI_ISteamFriends_RequestUserInformation},
+		{name: "ptrAPI_ISteamFriends_GetClanCount", value: ptrAPI_ISteamFriends_GetClanCount},
+		{name: "ptrAPI_ISteamFriends_GetClanByIndex", value: ptrAPI_ISteamFriends_GetClanByIndex},
+		{name: "ptrAPI_ISteamFriends_GetClanName", value: ptrAPI_ISteamFriends_GetClanName},
+		{name: "ptrAPI_ISteamFriends_GetClanTag", value: ptrAPI_ISteamFriends_GetClanTag},
+		{name: "ptrAPI_ISteamFriends_GetClanActivityCounts", value: ptrAPI_ISteamFriends_GetClanActivityCounts},
+		{name: "ptrAPI_ISteamFriends_DownloadClanActivityCounts", value: ptrAPI_ISteamFriends_DownloadClanActivityCounts},
+		{name: "ptrAPI_ISteamFriends_GetClanOwner", value: ptrAPI_ISteamFriends_GetClanOwner},
+		{name: "ptrAPI_ISteamFriends_GetClanOfficerCount", value: ptrAPI_ISteamFriends_GetClanOfficerCount},
+		{name: "ptrAPI_ISteamFriends_GetClanOfficerByIndex", value: ptrAPI_ISteamFriends_GetClanOfficerByIndex},
+		{name: "ptrAPI_ISteamFriends_RequestClanOfficerList", value: ptrAPI_ISteamFriends_RequestClanOfficerList},
+		{name: "ptrAPI_ISteamFriends_IsClanPublic", value: ptrAPI_ISteamFriends_IsClanPublic},
+		{name: "ptrAPI_ISteamFriends_IsClanOfficialGameGroup", value: ptrAPI_ISteamFriends_IsClanOfficialGameGroup},
+		{name: "ptrAPI_ISteamFriends_JoinClanChatRoom", value: ptrAPI_ISteamFriends_JoinClanChatRoom},
+		{name: "ptrAPI_ISteamFriends_LeaveClanChatRoom", value: ptrAPI_ISteamFriends_LeaveClanChatRoom},
+		{name: "ptrAPI_ISteamFriends_GetClanChatMemberCount", value: ptrAPI_ISteamFriends_GetClanChatMemberCount},
+		{name: "ptrAPI_ISteamFriends_GetChatMemberByIndex", value: ptrAPI_ISteamFriends_GetChatMemberByIndex},
+		{name: "ptrAPI_ISteamFriends_SendClanChatMessage", value: ptrAPI_ISteamFriends_SendClanChatMessage},
+		{name: "ptrAPI_ISteamFriends_GetClanChatMessage", value: ptrAPI_ISteamFriends_GetClanChatMessage},
+		{name: "ptrAPI_ISteamFriends_IsClanChatAdmin", value: ptrAPI_ISteamFriends_IsClanChatAdmin},
+		{name: "ptrAPI_ISteamFriends_IsClanChatWindowOpenInSteam", value: ptrAPI_ISteamFriends_IsClanChatWindowOpenInSteam},
+		{name: "ptrAPI_ISteamFriends_OpenClanChatWindowInSteam", value: ptrAPI_ISteamFriends_OpenClanChatWindowInSteam},
+		{name: "ptrAPI_ISteamFriends_CloseClanChatWindowInSteam", value: ptrAPI_ISteamFriends_CloseClanChatWindowInSteam},
 
 		{name: "ptrAPI_SteamMatchmaking", value: ptrAPI_SteamMatchmaking},
 		{name: "ptrAPI_ISteamMatchmaking_GetFavoriteGameCount", value: ptrAPI_ISteamMatchmaking_GetFavoriteGameCount},
@@ -1150,6 +1183,39 @@ func signatureExpectations() []signatureExpectation {
 		{name: "ptrAPI_ISteamFriends_ActivateGameOverlayToStore", expected: (func(uintptr, AppId_t, EOverlayToStoreFlag))(nil)},
 		{name: "ptrAPI_ISteamFriends_ActivateGameOverlayInviteDialog", expected: (func(uintptr, CSteamID))(nil)},
 		{name: "ptrAPI_ISteamFriends_ActivateGameOverlayInviteDialogConnectString", expected: (func(uintptr, string))(nil)},
+		{name: "ptrAPI_ISteamFriends_ClearRichPresence", expected: (func(uintptr))(nil)},
+		{name: "ptrAPI_ISteamFriends_GetFriendRichPresence", expected: (func(uintptr, CSteamID, string) string)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetFriendRichPresenceKeyCount", expected: (func(uintptr, CSteamID) int32)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetFriendRichPresenceKeyByIndex", expected: (func(uintptr, CSteamID, int32) string)(nil)},
+		{name: "ptrAPI_ISteamFriends_RequestFriendRichPresence", expected: (func(uintptr, CSteamID))(nil)},
+		{name: "ptrAPI_ISteamFriends_GetFriendsGroupCount", expected: (func(uintptr) int32)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetFriendsGroupIDByIndex", expected: (func(uintptr, int32) FriendsGroupID_t)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetFriendsGroupName", expected: (func(uintptr, FriendsGroupID_t) string)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetFriendsGroupMembersCount", expected: (func(uintptr, FriendsGroupID_t) int32)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetFriendsGroupMembersList", expected: (func(uintptr, FriendsGroupID_t, uintptr, int32))(nil)},
+		{name: "ptrAPI_ISteamFriends_RequestUserInformation", expected: (func(uintptr, CSteamID, bool) bool)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetClanCount", expected: (func(uintptr) int32)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetClanByIndex", expected: (func(uintptr, int32) CSteamID)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetClanName", expected: (func(uintptr, CSteamID) string)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetClanTag", expected: (func(uintptr, CSteamID) string)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetClanActivityCounts", expected: (func(uintptr, CSteamID, uintptr, uintptr, uintptr) bool)(nil)},
+		{name: "ptrAPI_ISteamFriends_DownloadClanActivityCounts", expected: (func(uintptr, uintptr, int32) SteamAPICall_t)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetClanOwner", expected: (func(uintptr, CSteamID) CSteamID)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetClanOfficerCount", expected: (func(uintptr, CSteamID) int32)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetClanOfficerByIndex", expected: (func(uintptr, CSteamID, int32) CSteamID)(nil)},
+		{name: "ptrAPI_ISteamFriends_RequestClanOfficerList", expected: (func(uintptr, CSteamID) SteamAPICall_t)(nil)},
+		{name: "ptrAPI_ISteamFriends_IsClanPublic", expected: (func(uintptr, CSteamID) bool)(nil)},
+		{name: "ptrAPI_ISteamFriends_IsClanOfficialGameGroup", expected: (func(uintptr, CSteamID) bool)(nil)},
+		{name: "ptrAPI_ISteamFriends_JoinClanChatRoom", expected: (func(uintptr, CSteamID) SteamAPICall_t)(nil)},
+		{name: "ptrAPI_ISteamFriends_LeaveClanChatRoom", expected: (func(uintptr, CSteamID) bool)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetClanChatMemberCount", expected: (func(uintptr, CSteamID) int32)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetChatMemberByIndex", expected: (func(uintptr, CSteamID, int32) CSteamID)(nil)},
+		{name: "ptrAPI_ISteamFriends_SendClanChatMessage", expected: (func(uintptr, CSteamID, string) bool)(nil)},
+		{name: "ptrAPI_ISteamFriends_GetClanChatMessage", expected: (func(uintptr, CSteamID, int32, uintptr, int32, uintptr, uintptr) int32)(nil)},
+		{name: "ptrAPI_ISteamFriends_IsClanChatAdmin", expected: (func(uintptr, CSteamID, CSteamID) bool)(nil)},
+		{name: "ptrAPI_ISteamFriends_IsClanChatWindowOpenInSteam", expected: (func(uintptr, CSteamID) bool)(nil)},
+		{name: "ptrAPI_ISteamFriends_OpenClanChatWindowInSteam", expected: (func(uintptr, CSteamID) bool)(nil)},
+		{name: "ptrAPI_ISteamFriends_CloseClanChatWindowInSteam", expected: (func(uintptr, CSteamID) bool)(nil)},
 
 		{name: "ptrAPI_SteamMatchmaking", expected: (func() uintptr)(nil)},
 		{name: "ptrAPI_ISteamMatchmaking_GetFavoriteGameCount", expected: (func(uintptr) int32)(nil)},
@@ -1479,6 +1545,39 @@ func allFlatAPISymbols() []string {
 		flatAPI_ISteamFriends_ActivateGameOverlayToStore,
 		flatAPI_ISteamFriends_ActivateGameOverlayInviteDialog,
 		flatAPI_ISteamFriends_ActivateGameOverlayInviteDialogConnectString,
+		flatAPI_ISteamFriends_ClearRichPresence,
+		flatAPI_ISteamFriends_GetFriendRichPresence,
+		flatAPI_ISteamFriends_GetFriendRichPresenceKeyCount,
+		flatAPI_ISteamFriends_GetFriendRichPresenceKeyByIndex,
+		flatAPI_ISteamFriends_RequestFriendRichPresence,
+		flatAPI_ISteamFriends_GetFriendsGroupCount,
+		flatAPI_ISteamFriends_GetFriendsGroupIDByIndex,
+		flatAPI_ISteamFriends_GetFriendsGroupName,
+		flatAPI_ISteamFriends_GetFriendsGroupMembersCount,
+		flatAPI_ISteamFriends_GetFriendsGroupMembersList,
+		flatAPI_ISteamFriends_RequestUserInformation,
+		flatAPI_ISteamFriends_GetClanCount,
+		flatAPI_ISteamFriends_GetClanByIndex,
+		flatAPI_ISteamFriends_GetClanName,
+		flatAPI_ISteamFriends_GetClanTag,
+		flatAPI_ISteamFriends_GetClanActivityCounts,
+		flatAPI_ISteamFriends_DownloadClanActivityCounts,
+		flatAPI_ISteamFriends_GetClanOwner,
+		flatAPI_ISteamFriends_GetClanOfficerCount,
+		flatAPI_ISteamFriends_GetClanOfficerByIndex,
+		flatAPI_ISteamFriends_RequestClanOfficerList,
+		flatAPI_ISteamFriends_IsClanPublic,
+		flatAPI_ISteamFriends_IsClanOfficialGameGroup,
+		flatAPI_ISteamFriends_JoinClanChatRoom,
+		flatAPI_ISteamFriends_LeaveClanChatRoom,
+		flatAPI_ISteamFriends_GetClanChatMemberCount,
+		flatAPI_ISteamFriends_GetChatMemberByIndex,
+		flatAPI_ISteamFriends_SendClanChatMessage,
+		flatAPI_ISteamFriends_GetClanChatMessage,
+		flatAPI_ISteamFriends_IsClanChatAdmin,
+		flatAPI_ISteamFriends_IsClanChatWindowOpenInSteam,
+		flatAPI_ISteamFriends_OpenClanChatWindowInSteam,
+		flatAPI_ISteamFriends_CloseClanChatWindowInSteam,
 
 		flatAPI_SteamMatchmaking,
 		flatAPI_ISteamMatchmaking_GetFavoriteGameCount,
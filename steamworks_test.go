@@ -119,6 +119,26 @@ func TestFriendsIterator(t *testing.T) {
 	_ = s.Friends(EFriendFlagImmediate)
 }
 
+func TestFriendRichPresenceIterator(t *testing.T) {
+	var s steamFriends
+	var friend CSteamID
+	_ = s.FriendRichPresence(friend)
+}
+
+func TestFriendsGroupsIterators(t *testing.T) {
+	var s steamFriends
+	_ = s.FriendsGroups()
+	_ = s.FriendsGroupMembers(FriendsGroupIDInvalid)
+}
+
+func TestClanIterators(t *testing.T) {
+	var s steamFriends
+	var clanID CSteamID
+	_ = s.Clans()
+	_ = s.ClanOfficers(clanID)
+	_ = s.ClanChatMembers(clanID)
+}
+
 func TestLobbyMembersIterator(t *testing.T) {
 	var s steamMatchmaking
 	var lobbyID CSteamID
@@ -158,3 +178,10 @@ func TestLobbyChatMsgLayout(t *testing.T) {
 		t.Fatalf("LobbyChatMsg.EntryType()=%v, want %v", got, want)
 	}
 }
+
+func TestFriendRichPresenceUpdateLayout(t *testing.T) {
+	var update FriendRichPresenceUpdate
+	if got, want := unsafe.Offsetof(update.AppID), uintptr(8); got != want {
+		t.Fatalf("FriendRichPresenceUpdate.AppID offset=%d, want %d", got, want)
+	}
+}
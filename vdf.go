@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+var ErrVDFSyntax = errors.New("steamworks: vdf syntax error")
+
+// vdfNode is a KeyValues entry: either a string value or a list of children.
+type vdfNode struct {
+	key      string
+	value    string
+	children []*vdfNode
+}
+
+// child returns the first child whose key matches name case-insensitively, as
+// KeyValues lookups do.
+func (n *vdfNode) child(name string) *vdfNode {
+	for _, c := range n.children {
+		if strings.EqualFold(c.key, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// parseVDF parses Valve KeyValues text, decoding UTF-16 and UTF-8 byte order
+// marks. The returned root node holds the top-level entries as children.
+func parseVDF(data []byte) (*vdfNode, error) {
+	p := vdfParser{src: []rune(decodeVDFText(data))}
+	root := &vdfNode{}
+	if err := p.parseChildren(root, false); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func decodeVDFText(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xfe}):
+		return decodeUTF16(data[2:], func(b []byte) uint16 { return uint16(b[0]) | uint16(b[1])<<8 })
+	case bytes.HasPrefix(data, []byte{0xfe, 0xff}):
+		return decodeUTF16(data[2:], func(b []byte) uint16 { return uint16(b[1]) | uint16(b[0])<<8 })
+	default:
+		return string(bytes.TrimPrefix(data, []byte{0xef, 0xbb, 0xbf}))
+	}
+}
+
+func decodeUTF16(data []byte, unit func([]byte) uint16) string {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		units = append(units, unit(data[i:i+2]))
+	}
+	return string(utf16.Decode(units))
+}
+
+type vdfParser struct {
+	src  []rune
+	pos  int
+	line int
+}
+
+func (p *vdfParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrVDFSyntax, p.line+1, fmt.Sprintf(format, args...))
+}
+
+func (p *vdfParser) parseChildren(parent *vdfNode, nested bool) error {
+	for {
+		tok, quoted, ok := p.next()
+		if !ok {
+			if nested {
+				return p.errorf("unexpected end of input")
+			}
+			return nil
+		}
+		if !quoted && tok == "}" {
+			if !nested {
+				return p.errorf("unexpected '}'")
+			}
+			return nil
+		}
+		if !quoted && tok == "{" {
+			return p.errorf("unexpected '{'")
+		}
+
+		node := &vdfNode{key: tok}
+		val, valQuoted, ok := p.next()
+		if !ok {
+			return p.errorf("missing value for %q", tok)
+		}
+		switch {
+		case !valQuoted && val == "{":
+			if err := p.parseChildren(node, true); err != nil {
+				return err
+			}
+		case !valQuoted && val == "}":
+			return p.errorf("missing value for %q", tok)
+		default:
+			node.value = val
+		}
+		parent.children = append(parent.children, node)
+	}
+}
+
+// next returns the next token, skipping whitespace, comments and platform
+// conditionals such as [$WIN32].
+func (p *vdfParser) next() (tok string, quoted bool, ok bool) {
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		switch {
+		case r == '\n':
+			p.line++
+			p.pos++
+		case r == ' ' || r == '\t' || r == '\r':
+			p.pos++
+		case r == '/' && p.pos+1 < len(p.src) && p.src[p.pos+1] == '/':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case r == '[':
+			for p.pos < len(p.src) && p.src[p.pos] != ']' {
+				p.pos++
+			}
+			p.pos++
+		case r == '{' || r == '}':
+			p.pos++
+			return string(r), false, true
+		case r == '"':
+			return p.quoted(), true, true
+		default:
+			start := p.pos
+			for p.pos < len(p.src) && !strings.ContainsRune(" \t\r\n{}\"", p.src[p.pos]) {
+				p.pos++
+			}
+			return string(p.src[start:p.pos]), false, true
+		}
+	}
+	return "", false, false
+}
+
+func (p *vdfParser) quoted() string {
+	var b strings.Builder
+	p.pos++ // opening quote
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		p.pos++
+		switch r {
+		case '"':
+			return b.String()
+		case '\n':
+			p.line++
+		case '\\':
+			if p.pos < len(p.src) {
+				esc := p.src[p.pos]
+				p.pos++
+				switch esc {
+				case 'n':
+					r = '\n'
+				case 't':
+					r = '\t'
+				default:
+					r = esc
+				}
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
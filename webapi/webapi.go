@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+// Package webapi is a minimal Steam Web API client for game backends.
+//
+// It validates tickets minted on the client with
+// ISteamUser.GetAuthTicketForWebApi by calling
+// ISteamUserAuth/AuthenticateUserTicket, and reports results with the same
+// SteamID and EResult types used by the steamworks package.
+package webapi
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/badhex/go-steamworks"
+)
+
+// DefaultBaseURL is the partner Web API host. Publisher keys must be used
+// against the partner host; api.steampowered.com only accepts user keys.
+const DefaultBaseURL = "https://partner.steam-api.com"
+
+// KeyHeader is the request header carrying the publisher Web API key.
+const KeyHeader = "x-webapi-key"
+
+var (
+	ErrMissingKey    = errors.New("webapi: publisher key is empty")
+	ErrEmptyTicket   = errors.New("webapi: ticket is empty")
+	ErrInvalidAnswer = errors.New("webapi: malformed response")
+)
+
+// Error is returned when Steam rejects a request. Result is derived from the
+// Web API error code when the body carries one and from the HTTP status
+// otherwise; Code keeps the raw Web API error code.
+type Error struct {
+	Result     steamworks.EResult
+	Code       int32
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("webapi: %s (result %d, status %d)", e.Message, e.Result, e.StatusCode)
+	}
+	return fmt.Sprintf("webapi: request failed (result %d, status %d)", e.Result, e.StatusCode)
+}
+
+// ResultOf returns the EResult carried by err, EResultOK for a nil error and
+// EResultFail for errors that did not come from Steam.
+func ResultOf(err error) steamworks.EResult {
+	if err == nil {
+		return steamworks.EResultOK
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Result
+	}
+	return steamworks.EResultFail
+}
+
+// Client calls the Steam Web API with a publisher key.
+type Client struct {
+	// BaseURL defaults to DefaultBaseURL.
+	BaseURL string
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// Key is the publisher Web API key sent in KeyHeader.
+	Key string
+}
+
+// NewClient returns a Client for the partner Web API using publisherKey.
+func NewClient(publisherKey string) *Client {
+	return &Client{BaseURL: DefaultBaseURL, Key: publisherKey}
+}
+
+// UserTicket is the validated identity behind a Web API auth ticket.
+type UserTicket struct {
+	Result          steamworks.EResult
+	SteamID         steamworks.CSteamID
+	OwnerSteamID    steamworks.CSteamID
+	VACBanned       bool
+	PublisherBanned bool
+}
+
+// IsFamilyShared reports whether the game is borrowed from another account.
+func (t UserTicket) IsFamilyShared() bool {
+	return t.OwnerSteamID != 0 && t.OwnerSteamID != t.SteamID
+}
+
+// AuthenticateUserTicket validates a ticket from ISteamUser.GetAuthTicketForWebApi.
+// identity must match the identity string the client passed when minting the
+// ticket, and may be empty if none was used.
+func (c *Client) AuthenticateUserTicket(ctx context.Context, appID steamworks.AppId_t, ticket []byte, identity string) (UserTicket, error) {
+	if len(ticket) == 0 {
+		return UserTicket{}, ErrEmptyTicket
+	}
+	query := url.Values{}
+	query.Set("appid", strconv.FormatUint(uint64(appID), 10))
+	query.Set("ticket", strings.ToUpper(hex.EncodeToString(ticket)))
+	if identity != "" {
+		query.Set("identity", identity)
+	}
+
+	var body struct {
+		Response struct {
+			Params *struct {
+				Result          string `json:"result"`
+				SteamID         string `json:"steamid"`
+				OwnerSteamID    string `json:"ownersteamid"`
+				VACBanned       bool   `json:"vacbanned"`
+				PublisherBanned bool   `json:"publisherbanned"`
+			} `json:"params"`
+			Error *apiError `json:"error"`
+		} `json:"response"`
+	}
+	status, err := c.get(ctx, "ISteamUserAuth/AuthenticateUserTicket/v1/", query, &body)
+	if err != nil {
+		return UserTicket{}, err
+	}
+	if e := body.Response.Error; e != nil {
+		return UserTicket{}, e.toError(status)
+	}
+	params := body.Response.Params
+	if params == nil {
+		return UserTicket{}, ErrInvalidAnswer
+	}
+
+	out := UserTicket{
+		Result:          parseResult(params.Result),
+		VACBanned:       params.VACBanned,
+		PublisherBanned: params.PublisherBanned,
+	}
+	if out.SteamID, err = parseSteamID(params.SteamID); err != nil {
+		return UserTicket{}, err
+	}
+	if out.OwnerSteamID, err = parseSteamID(params.OwnerSteamID); err != nil {
+		return UserTicket{}, err
+	}
+	if out.Result != steamworks.EResultOK {
+		return out, &Error{Result: out.Result, StatusCode: status, Message: "ticket rejected: " + params.Result}
+	}
+	return out, nil
+}
+
+type apiError struct {
+	Code int32  `json:"errorcode"`
+	Desc string `json:"errordesc"`
+}
+
+func (e *apiError) toError(status int) *Error {
+	return &Error{Result: resultForErrorCode(e.Code), Code: e.Code, StatusCode: status, Message: e.Desc}
+}
+
+func (c *Client) get(ctx context.Context, method string, query url.Values, out any) (int, error) {
+	if c.Key == "" {
+		return 0, ErrMissingKey
+	}
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	endpoint := strings.TrimSuffix(base, "/") + "/" + method + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set(KeyHeader, c.Key)
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Some failures still carry a JSON error body with a precise code.
+		var body struct {
+			Response struct {
+				Error *apiError `json:"error"`
+			} `json:"response"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&body) == nil && body.Response.Error != nil {
+			return resp.StatusCode, body.Response.Error.toError(resp.StatusCode)
+		}
+		return resp.StatusCode, &Error{Result: resultForStatus(resp.StatusCode), StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("%w: %v", ErrInvalidAnswer, err)
+	}
+	return resp.StatusCode, nil
+}
+
+// resultForErrorCode maps AuthenticateUserTicket error codes onto EResult.
+func resultForErrorCode(code int32) steamworks.EResult {
+	switch code {
+	case 3:
+		return steamworks.EResultInvalidParam
+	case 100, 101, 102:
+		// Invalid ticket, ticket for another app, or ticket for another identity.
+		return steamworks.EResultAccessDenied
+	case 103:
+		return steamworks.EResultExpired
+	case 104:
+		return steamworks.EResultBanned
+	default:
+		return steamworks.EResultFail
+	}
+}
+
+func resultForStatus(status int) steamworks.EResult {
+	switch status {
+	case http.StatusBadRequest:
+		return steamworks.EResultInvalidParam
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return steamworks.EResultAccessDenied
+	case http.StatusTooManyRequests:
+		return steamworks.EResultRateLimitExceeded
+	case http.StatusServiceUnavailable:
+		return steamworks.EResultServiceUnavailable
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return steamworks.EResultTimeout
+	default:
+		return steamworks.EResultFail
+	}
+}
+
+func parseResult(s string) steamworks.EResult {
+	switch s {
+	case "OK":
+		return steamworks.EResultOK
+	case "":
+		return steamworks.EResultNone
+	}
+	if n, err := strconv.ParseInt(s, 10, 32); err == nil {
+		return steamworks.EResult(n)
+	}
+	return steamworks.EResultFail
+}
+
+func parseSteamID(s string) (steamworks.CSteamID, error) {
+	if s == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: steamid %q", ErrInvalidAnswer, s)
+	}
+	return steamworks.CSteamID(id), nil
+}
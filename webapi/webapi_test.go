@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2025 The go-steamworks Authors
+
+package webapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/badhex/go-steamworks"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := NewClient("secret")
+	c.BaseURL = srv.URL
+	c.HTTPClient = srv.Client()
+	return c
+}
+
+func TestAuthenticateUserTicket(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Path, "/ISteamUserAuth/AuthenticateUserTicket/v1/"; got != want {
+			t.Errorf("path=%q, want %q", got, want)
+		}
+		if got := r.Header.Get(KeyHeader); got != "secret" {
+			t.Errorf("%s=%q, want %q", KeyHeader, got, "secret")
+		}
+		q := r.URL.Query()
+		if got, want := q.Get("appid"), "480"; got != want {
+			t.Errorf("appid=%q, want %q", got, want)
+		}
+		if got, want := q.Get("ticket"), "0A1BFF"; got != want {
+			t.Errorf("ticket=%q, want %q", got, want)
+		}
+		if got, want := q.Get("identity"), "backend"; got != want {
+			t.Errorf("identity=%q, want %q", got, want)
+		}
+		w.Write([]byte(`{"response":{"params":{"result":"OK","steamid":"76561197960287930","ownersteamid":"76561197960287931","vacbanned":false,"publisherbanned":true}}}`))
+	})
+
+	got, err := c.AuthenticateUserTicket(context.Background(), 480, []byte{0x0a, 0x1b, 0xff}, "backend")
+	if err != nil {
+		t.Fatalf("AuthenticateUserTicket error=%v", err)
+	}
+	want := UserTicket{
+		Result:          steamworks.EResultOK,
+		SteamID:         76561197960287930,
+		OwnerSteamID:    76561197960287931,
+		PublisherBanned: true,
+	}
+	if got != want {
+		t.Fatalf("AuthenticateUserTicket=%+v, want %+v", got, want)
+	}
+	if !got.IsFamilyShared() {
+		t.Fatalf("IsFamilyShared()=false, want true")
+	}
+}
+
+func TestAuthenticateUserTicketErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   steamworks.EResult
+	}{
+		{name: "steam-error", status: http.StatusOK, body: `{"response":{"error":{"errorcode":3,"errordesc":"Invalid parameter"}}}`, want: steamworks.EResultInvalidParam},
+		{name: "invalid-ticket", status: http.StatusOK, body: `{"response":{"error":{"errorcode":101,"errordesc":"Invalid ticket"}}}`, want: steamworks.EResultAccessDenied},
+		{name: "rejected", status: http.StatusOK, body: `{"response":{"params":{"result":"27","steamid":"1"}}}`, want: steamworks.EResultExpired},
+		{name: "forbidden", status: http.StatusForbidden, body: `<html>Forbidden</html>`, want: steamworks.EResultAccessDenied},
+		{name: "rate-limited", status: http.StatusTooManyRequests, want: steamworks.EResultRateLimitExceeded},
+		{name: "status-with-body", status: http.StatusBadRequest, body: `{"response":{"error":{"errorcode":103,"errordesc":"Ticket expired"}}}`, want: steamworks.EResultExpired},
+		{name: "unavailable", status: http.StatusServiceUnavailable, want: steamworks.EResultServiceUnavailable},
+	}
+	for _, tt := range tests {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		})
+		_, err := c.AuthenticateUserTicket(context.Background(), 480, []byte{1}, "")
+		var apiErr *Error
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%s: error=%v, want *Error", tt.name, err)
+		}
+		if got := ResultOf(err); got != tt.want {
+			t.Fatalf("%s: ResultOf=%d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAuthenticateUserTicketInvalidInput(t *testing.T) {
+	c := &Client{}
+	if _, err := c.AuthenticateUserTicket(context.Background(), 480, []byte{1}, ""); !errors.Is(err, ErrMissingKey) {
+		t.Fatalf("error=%v, want %v", err, ErrMissingKey)
+	}
+	if _, err := c.AuthenticateUserTicket(context.Background(), 480, nil, ""); !errors.Is(err, ErrEmptyTicket) {
+		t.Fatalf("error=%v, want %v", err, ErrEmptyTicket)
+	}
+
+	bad := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response":{}}`))
+	})
+	if _, err := bad.AuthenticateUserTicket(context.Background(), 480, []byte{1}, ""); !errors.Is(err, ErrInvalidAnswer) {
+		t.Fatalf("error=%v, want %v", err, ErrInvalidAnswer)
+	}
+	if got := ResultOf(nil); got != steamworks.EResultOK {
+		t.Fatalf("ResultOf(nil)=%d, want %d", got, steamworks.EResultOK)
+	}
+}